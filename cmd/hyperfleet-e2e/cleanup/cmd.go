@@ -0,0 +1,137 @@
+package cleanup
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/common"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Delete resources leaked by interrupted test runs",
+	Long: "Find clusters, namespaces, Maestro resource bundles, adapter Helm releases, ClusterRoles and\n" +
+		"Pub/Sub subscriptions left behind by killed or timed out runs and delete them.",
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var args struct {
+	olderThan     time.Duration
+	dryRun        bool
+	subscriptions bool
+	verbose       bool
+}
+
+func init() {
+	pfs := Cmd.PersistentFlags()
+
+	pfs.DurationVar(&args.olderThan, "older-than", 2*time.Hour,
+		"Only consider resources older than this duration")
+	pfs.BoolVar(&args.dryRun, "dry-run", false,
+		"List leaked resources without deleting them")
+	pfs.BoolVar(&args.subscriptions, "include-subscriptions", false,
+		"Also delete Pub/Sub subscriptions of test adapters without a release, regardless of --older-than;\n"+
+			"only use it when no other run is deploying adapters")
+	pfs.BoolVar(&args.verbose, "verbose", false,
+		"Also list resources that were left in place because they do not belong to test clusters")
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	if err := common.LoadConfig(common.ConfigFile); err != nil {
+		log.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Bind root command flags (api-url, logging flags)
+	common.BindRootFlags(cmd)
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := logger.Init(&cfg.Log, version.Get().Version); err != nil {
+		log.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+
+	helper.SetSuiteConfig(cfg)
+	h := helper.New()
+
+	summary := h.CleanupLeakedResources(cmd.Context(), helper.JanitorOptions{
+		OlderThan:     args.olderThan,
+		DryRun:        args.dryRun,
+		Subscriptions: args.subscriptions,
+	})
+
+	if err := printSummary(cmd.OutOrStdout(), summary, args.dryRun, args.verbose); err != nil {
+		log.Printf("Error printing summary: %v\n", err)
+		os.Exit(1)
+	}
+
+	if errs := summary.Errors(); len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// printSummary prints each leaked resource followed by a per-kind count table.
+// Skipped resources are only counted unless verbose is set.
+func printSummary(w io.Writer, summary *helper.JanitorSummary, dryRun, verbose bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "KIND\tNAME\tAGE\tSTATUS")
+	for _, item := range summary.Items {
+		if item.Skipped != "" && !verbose {
+			continue
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", item.Kind, item.Name, item.Age.Round(time.Second), itemStatus(item, dryRun))
+	}
+	_, _ = fmt.Fprintln(tw)
+
+	counts := summary.Counts()
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	_, _ = fmt.Fprintln(tw, "KIND\tFOUND\tDELETED\tFAILED\tSKIPPED")
+	for _, kind := range kinds {
+		c := counts[kind]
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", kind, c.Found, c.Deleted, c.Failed, c.Skipped)
+	}
+
+	return tw.Flush()
+}
+
+// itemStatus describes what happened to a leaked resource
+func itemStatus(item helper.JanitorItem, dryRun bool) string {
+	switch {
+	case item.Err != nil:
+		return "failed: " + item.Err.Error()
+	case item.Deleted:
+		return "deleted"
+	case item.Skipped != "":
+		return "skipped: " + item.Skipped
+	case dryRun:
+		return "would delete"
+	default:
+		return "kept"
+	}
+}
@@ -43,8 +43,14 @@ func LoadConfig(configFlagValue string) error {
 
 	// 5. Add custom environment variable bindings
 	// These allow alternative env var names for specific config paths
-	_ = viper.BindEnv("adapters.cluster", "API_ADAPTERS_CLUSTER")
-	_ = viper.BindEnv("adapters.nodepool", "API_ADAPTERS_NODEPOOL")
+	config.BindEnv("adapters.cluster", "API_ADAPTERS_CLUSTER")
+	config.BindEnv("adapters.nodepool", "API_ADAPTERS_NODEPOOL")
+	config.BindEnv("tls.caFile", "HYPERFLEET_TLS_CA_FILE")
+	config.BindEnv("tls.certFile", "HYPERFLEET_TLS_CERT_FILE")
+	config.BindEnv("tls.keyFile", "HYPERFLEET_TLS_KEY_FILE")
+	config.BindEnv("tls.serverName", "HYPERFLEET_TLS_SERVER_NAME")
+	config.BindEnv("tls.insecureSkipVerify", "HYPERFLEET_TLS_INSECURE_SKIP_VERIFY")
+	config.BindEnv("retry.maxBackoff", "HYPERFLEET_RETRY_MAX_BACKOFF")
 
 	return nil
 }
@@ -79,7 +85,7 @@ func bindStructFields(t reflect.Type, prefix string) {
 		// Convert dots to underscores: api.url -> HYPERFLEET_API_URL
 		envVar := config.EnvVar(strings.ToUpper(strings.ReplaceAll(configPath, ".", "_")))
 
-		_ = viper.BindEnv(configPath, envVar)
+		config.BindEnv(configPath, envVar)
 
 		fieldType := field.Type
 		if fieldType.Kind() == reflect.Struct {
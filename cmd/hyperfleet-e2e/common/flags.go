@@ -0,0 +1,39 @@
+package common
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+// AddFilterFlags registers the Ginkgo spec filter flags shared by commands that select specs
+func AddFilterFlags(cmd *cobra.Command) {
+	pfs := cmd.PersistentFlags()
+	pfs.String("label-filter", "", "Ginkgo label filter expression")
+	pfs.String("focus", "", "Only run tests matching this regex")
+	pfs.String("skip", "", "Skip tests matching this regex")
+}
+
+// BindFilterFlags binds the Ginkgo spec filter flags and their environment variables to viper
+// Must be called after LoadConfig so flags take priority over the config file
+func BindFilterFlags(cmd *cobra.Command) {
+	pfs := cmd.Flags()
+	config.BindFlag(config.Tests.GinkgoLabelFilter, pfs.Lookup("label-filter"))
+	config.BindFlag(config.Tests.GinkgoFocus, pfs.Lookup("focus"))
+	config.BindFlag(config.Tests.GinkgoSkip, pfs.Lookup("skip"))
+
+	config.BindEnv(config.Tests.GinkgoLabelFilter, "GINKGO_LABEL_FILTER")
+	config.BindEnv(config.Tests.GinkgoFocus, "GINKGO_FOCUS")
+	config.BindEnv(config.Tests.GinkgoSkip, "GINKGO_SKIP")
+}
+
+// BindRootFlags binds the root command persistent flags (profile, api-url, logging flags) to viper
+// Must be called after LoadConfig so flags take priority over the config file
+func BindRootFlags(cmd *cobra.Command) {
+	rootFlags := cmd.Root().PersistentFlags()
+	config.BindFlag(config.ProfileKey, rootFlags.Lookup("profile"))
+	config.BindFlag(config.API.URL, rootFlags.Lookup("api-url"))
+	config.BindFlag(config.Log.Level, rootFlags.Lookup("log-level"))
+	config.BindFlag(config.Log.Format, rootFlags.Lookup("log-format"))
+	config.BindFlag(config.Log.Output, rootFlags.Lookup("log-output"))
+}
@@ -0,0 +1,134 @@
+package configcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/common"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/e2e"
+
+	// Import test registry (which imports all test suites)
+	_ "github.com/openshift-hyperfleet/hyperfleet-e2e/e2e"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the effective configuration",
+	Long:  "Inspect the configuration merged from flags, environment variables, the config file and defaults.",
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration with the source of each value",
+	Long: "Print every configuration value with where it came from: flag, env (with the variable name),\n" +
+		"file or default. Credentials in URLs are redacted.",
+	Args: cobra.NoArgs,
+	Run:  runShow,
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the effective configuration",
+	Long: "Validate the effective configuration and exit non-zero if it has problems.\n" +
+		"Honors the same --label-filter, --focus and --skip flags as the test command to decide\n" +
+		"whether adapterDeployment settings are required.",
+	Args: cobra.NoArgs,
+	Run:  runValidate,
+}
+
+var args struct {
+	output string
+}
+
+func init() {
+	showCmd.Flags().StringVarP(&args.output, "output", "o", outputText,
+		"Output format (text, json)")
+
+	common.AddFilterFlags(validateCmd)
+
+	Cmd.AddCommand(showCmd)
+	Cmd.AddCommand(validateCmd)
+}
+
+func runShow(cmd *cobra.Command, argv []string) {
+	if args.output != outputText && args.output != outputJSON {
+		log.Printf("Error: unsupported output format %q (expected %s or %s)\n", args.output, outputText, outputJSON)
+		os.Exit(1)
+	}
+
+	cfg := resolve(cmd)
+
+	var err error
+	switch args.output {
+	case outputJSON:
+		err = printJSON(cmd.OutOrStdout(), cfg.Fields())
+	default:
+		err = printText(cmd.OutOrStdout(), cfg.Fields())
+	}
+	if err != nil {
+		log.Printf("Error printing configuration: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runValidate(cmd *cobra.Command, argv []string) {
+	cfg := resolve(cmd)
+
+	common.BindFilterFlags(cmd)
+	if e2e.DeploysAdapters() {
+		cfg.RequireAdapterDeployment()
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "configuration is valid")
+}
+
+// resolve loads the configuration the same way the test command does, without validating it
+func resolve(cmd *cobra.Command) *config.Config {
+	if err := common.LoadConfig(common.ConfigFile); err != nil {
+		log.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Bind root command flags (api-url, logging flags)
+	common.BindRootFlags(cmd)
+
+	cfg, err := config.Resolve()
+	if err != nil {
+		log.Printf("Error resolving config: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// printText prints a KEY/VALUE/SOURCE table
+func printText(w io.Writer, fields []config.Field) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+	for _, field := range fields {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", field.Key, field.Value, field.Origin)
+	}
+	return tw.Flush()
+}
+
+// printJSON prints the fields as an indented JSON array
+func printJSON(w io.Writer, fields []config.Field) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(fields)
+}
@@ -0,0 +1,56 @@
+package doctor
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/common"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/e2e"
+
+	// Import test registry (which imports all test suites)
+	_ "github.com/openshift-hyperfleet/hyperfleet-e2e/e2e"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment before running tests",
+	Long: "Check that the API, Kubernetes, Maestro, the required binaries and the preinstalled adapters are\n" +
+		"available, and that adapterDeployment is complete when the selected specs deploy adapters.\n" +
+		"Honors the same --label-filter, --focus and --skip flags as the test command.",
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	common.AddFilterFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	if err := common.LoadConfig(common.ConfigFile); err != nil {
+		log.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Bind flags after config loading (osde2e pattern)
+	common.BindFilterFlags(cmd)
+	common.BindRootFlags(cmd)
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	report := e2e.RunPreflight(cmd.Context(), cfg)
+	if err := report.Print(cmd.OutOrStdout()); err != nil {
+		log.Printf("Error printing report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !report.Passed() {
+		os.Exit(1)
+	}
+}
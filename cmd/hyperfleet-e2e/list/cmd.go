@@ -0,0 +1,92 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/common"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/e2e"
+
+	// Import test registry (which imports all test suites)
+	_ "github.com/openshift-hyperfleet/hyperfleet-e2e/e2e"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered test specs",
+	Long: "List the registered test specs with their labels and suite tags without running them.\n" +
+		"Honors the same --label-filter, --focus and --skip flags as the test command.",
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var args struct {
+	output string
+}
+
+func init() {
+	pfs := Cmd.PersistentFlags()
+
+	common.AddFilterFlags(Cmd)
+	pfs.StringVarP(&args.output, "output", "o", outputText,
+		"Output format (text, json)")
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	if args.output != outputText && args.output != outputJSON {
+		log.Printf("Error: unsupported output format %q (expected %s or %s)\n", args.output, outputText, outputJSON)
+		os.Exit(1)
+	}
+
+	if err := common.LoadConfig(common.ConfigFile); err != nil {
+		log.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Bind flags after config loading (osde2e pattern)
+	common.BindFilterFlags(cmd)
+
+	specs := e2e.ListSpecs()
+
+	var err error
+	switch args.output {
+	case outputJSON:
+		err = printJSON(cmd.OutOrStdout(), specs)
+	default:
+		err = printText(cmd.OutOrStdout(), specs)
+	}
+	if err != nil {
+		log.Printf("Error printing specs: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printText prints one spec per line as "<Describe path> > <It text> [labels]"
+func printText(w io.Writer, specs []e2e.SpecInfo) error {
+	for _, spec := range specs {
+		path := strings.Join(append(append([]string{}, spec.Hierarchy...), spec.Text), " > ")
+		if _, err := fmt.Fprintf(w, "%s [%s]\n", path, strings.Join(spec.Labels, ", ")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d specs\n", len(specs))
+	return err
+}
+
+// printJSON prints the specs as an indented JSON array
+func printJSON(w io.Writer, specs []e2e.SpecInfo) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(specs)
+}
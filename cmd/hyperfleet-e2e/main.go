@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/cleanup"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/common"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/configcmd"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/doctor"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/list"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/reportcmd"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/test"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/versioncmd"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/interrupt"
+	buildinfo "github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
 )
 
 var root = &cobra.Command{
@@ -26,6 +35,7 @@ var root = &cobra.Command{
 func init() {
 	pfs := root.PersistentFlags()
 	pfs.StringVar(&configFile, "config", "", "config file path")
+	pfs.StringVar(&profile, "profile", "", "Config file profile to overlay on the base configuration")
 	pfs.StringVar(&apiURL, "api-url", "", "HyperFleet API URL")
 	pfs.StringVar(&logLevel, "log-level", config.DefaultLogLevel, "Log level (debug, info, warn, error)")
 	pfs.StringVar(&logFormat, "log-format", config.DefaultLogFormat, "Log format (text, json)")
@@ -34,10 +44,26 @@ func init() {
 	// Flags are bound in subcommand run() after config loading (osde2e pattern)
 
 	root.AddCommand(test.Cmd)
+	root.AddCommand(list.Cmd)
+	root.AddCommand(cleanup.Cmd)
+	root.AddCommand(doctor.Cmd)
+	root.AddCommand(configcmd.Cmd)
+	root.AddCommand(reportcmd.Cmd)
+	root.AddCommand(versioncmd.Cmd)
 }
 
+// Build information, set with -X ldflags by the Makefile
+var (
+	version            = "dev"
+	commit             = ""
+	date               = ""
+	openapiSpecHash    = ""
+	openapiSpecVersion = ""
+)
+
 var (
 	configFile string
+	profile    string
 	apiURL     string
 	logLevel   string
 	logFormat  string
@@ -45,8 +71,21 @@ var (
 )
 
 func main() {
-	if err := root.Execute(); err != nil {
+	buildinfo.Set(buildinfo.Info{
+		Version:            version,
+		Commit:             commit,
+		Date:               date,
+		OpenAPISpecHash:    openapiSpecHash,
+		OpenAPISpecVersion: openapiSpecVersion,
+	})
+
+	// The first SIGINT/SIGTERM cancels the command context so runs can clean up, a second one exits immediately
+	ctx, stop := interrupt.NotifyContext(context.Background())
+	defer stop()
+
+	if err := root.ExecuteContext(ctx); err != nil {
 		log.Printf("Error: %v\n", err)
+		stop()
 		os.Exit(1)
 	}
 }
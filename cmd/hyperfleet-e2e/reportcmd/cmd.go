@@ -0,0 +1,150 @@
+package reportcmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "report",
+	Short: "Work with test reports",
+	Long:  "Combine and inspect the reports written by the test command.",
+}
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge -o OUTPUT JUNIT_FILE...",
+	Short: "Merge the JUnit reports of several shards into one",
+	Long: "Merge the JUnit files written by sharded test runs (--shard-index/--shard-count) into one report.\n" +
+		"Each shard lists the specs of the other shards as skipped; the merged report keeps the\n" +
+		"result of the shard that ran each spec.",
+	Args: cobra.MinimumNArgs(1),
+	Run:  runMerge,
+}
+
+var htmlCmd = &cobra.Command{
+	Use:   "html -o OUTPUT REPORT_FILE...",
+	Short: "Render JUnit or Ginkgo JSON reports as a self-contained HTML page",
+	Long: "Render the JUnit or Ginkgo JSON reports (report.json in the output directory) of one or more runs\n" +
+		"as a single HTML file that works offline. Specs can be filtered by report, suite, tier, label and\n" +
+		"state; Ginkgo JSON reports also provide ginkgo.By step timelines. Artifacts saved by specs are\n" +
+		"linked relative to the directory of each report, or to --artifacts-dir.",
+	Args: cobra.MinimumNArgs(1),
+	Run:  runHTML,
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff BASELINE CANDIDATE",
+	Short: "Compare two runs and list regressions",
+	Long: "Compare a candidate run with a baseline run, e.g. main's last green run. Each argument is a\n" +
+		"report file or an output directory, whose report*.json files (or *.xml files) are read together.\n" +
+		"Lists new failures, fixed specs, added and removed specs, and specs or ginkgo.By steps whose\n" +
+		"duration grew by more than --threshold percent. Step durations need Ginkgo JSON reports.\n" +
+		"With --exit-code the command exits 1 if there are new failures or duration regressions.",
+	Args: cobra.ExactArgs(2),
+	Run:  runDiff,
+}
+
+var args struct {
+	output       string
+	title        string
+	artifactsDir string
+	threshold    float64
+	minDuration  time.Duration
+	exitCode     bool
+}
+
+func init() {
+	mergeCmd.Flags().StringVarP(&args.output, "output", "o", "",
+		"Path to write the merged JUnit report")
+	_ = mergeCmd.MarkFlagRequired("output")
+
+	htmlCmd.Flags().StringVarP(&args.output, "output", "o", "",
+		"Path to write the HTML report")
+	_ = htmlCmd.MarkFlagRequired("output")
+	htmlCmd.Flags().StringVar(&args.title, "title", "",
+		"Page title (default \"HyperFleet E2E Report\")")
+	htmlCmd.Flags().StringVar(&args.artifactsDir, "artifacts-dir", "",
+		"Output directory the spec artifacts were saved to (default: the directory of each report)")
+
+	diffCmd.Flags().Float64Var(&args.threshold, "threshold", 30,
+		"Duration increase in percent reported as a regression")
+	diffCmd.Flags().DurationVar(&args.minDuration, "min-duration", 5*time.Second,
+		"Ignore specs and steps that took less than this in the baseline")
+	diffCmd.Flags().BoolVar(&args.exitCode, "exit-code", false,
+		"Exit with 1 if there are new failures or duration regressions")
+
+	Cmd.AddCommand(mergeCmd)
+	Cmd.AddCommand(htmlCmd)
+	Cmd.AddCommand(diffCmd)
+}
+
+func runMerge(cmd *cobra.Command, argv []string) {
+	inputs := make([]*report.JUnitTestSuites, 0, len(argv))
+	for _, path := range argv {
+		suites, err := report.ReadJUnit(path)
+		if err != nil {
+			log.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		inputs = append(inputs, suites)
+	}
+
+	merged, err := report.Merge(inputs)
+	if err != nil {
+		log.Printf("Error merging reports: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := report.WriteJUnit(args.output, merged); err != nil {
+		log.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "merged %d reports into %s: %d tests, %d failures, %d errors, %d skipped\n",
+		len(argv), args.output, merged.Tests, merged.Failures, merged.Errors, merged.Disabled)
+}
+
+func runHTML(cmd *cobra.Command, argv []string) {
+	var results []report.Result
+	for _, path := range argv {
+		loaded, err := report.LoadResults(path)
+		if err != nil {
+			log.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		results = append(results, loaded...)
+	}
+
+	opts := report.HTMLOptions{Title: args.title, ArtifactsDir: args.artifactsDir}
+	if err := report.WriteHTML(args.output, results, opts); err != nil {
+		log.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "wrote %d specs from %d reports to %s\n", len(results), len(argv), args.output)
+}
+
+func runDiff(cmd *cobra.Command, argv []string) {
+	baseline, err := report.LoadResultSet(argv[0])
+	if err != nil {
+		log.Printf("Error loading baseline: %v\n", err)
+		os.Exit(1)
+	}
+	candidate, err := report.LoadResultSet(argv[1])
+	if err != nil {
+		log.Printf("Error loading candidate: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := report.DiffOptions{Threshold: args.threshold / 100, MinDurationSeconds: args.minDuration.Seconds()}
+	diff := report.Compare(baseline, candidate, opts)
+	report.RenderDiff(cmd.OutOrStdout(), diff, opts)
+
+	if args.exitCode && diff.Regressed() {
+		os.Exit(1)
+	}
+}
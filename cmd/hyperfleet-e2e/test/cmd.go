@@ -3,13 +3,17 @@ package test
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/cmd/hyperfleet-e2e/common"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/e2e"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
 
 	// Import test registry (which imports all test suites)
 	_ "github.com/openshift-hyperfleet/hyperfleet-e2e/e2e"
@@ -24,24 +28,58 @@ var Cmd = &cobra.Command{
 }
 
 var args struct {
-	labelFilter string
-	focusTests  string
-	skipTests   string
-	junitReport string
+	junitReport         string
+	flakeAttempts       int
+	procs               int
+	shardIndex          int
+	shardCount          int
+	preflight           bool
+	skipLabelValidation bool
+	quarantineFile      string
+	quarantineMode      string
+	leakCheck           string
+	runID               string
+	gracePeriod         time.Duration
+	dryRun              bool
+	apiVersionCheck     string
+	httpRecord          string
 }
 
 func init() {
 	pfs := Cmd.PersistentFlags()
 
 	// Test control flags
-	pfs.StringVar(&args.labelFilter, "label-filter", "",
-		"Ginkgo label filter expression")
-	pfs.StringVar(&args.focusTests, "focus", "",
-		"Only run tests matching this regex")
-	pfs.StringVar(&args.skipTests, "skip", "",
-		"Skip tests matching this regex")
+	common.AddFilterFlags(Cmd)
 	pfs.StringVar(&args.junitReport, "junit-report", "",
 		"Path to write JUnit XML report")
+	pfs.IntVar(&args.flakeAttempts, "flake-attempts", 0,
+		"Run a failing spec up to this many times before it counts as failed")
+	pfs.IntVar(&args.procs, "procs", 1,
+		"Run specs in this many parallel processes (Serial specs run alone on process 1 at the end)")
+	pfs.IntVar(&args.shardIndex, "shard-index", 0,
+		"Zero-based shard of the filtered specs to run (requires --shard-count)")
+	pfs.IntVar(&args.shardCount, "shard-count", 1,
+		"Split the filtered specs into this many shards by hashing their full text")
+	pfs.BoolVar(&args.preflight, "preflight", false,
+		"Run the doctor checks before the suite and abort if any fails")
+	pfs.BoolVar(&args.skipLabelValidation, "skip-label-validation", false,
+		"Run specs with missing or unknown labels (local development only)")
+	pfs.StringVar(&args.quarantineFile, "quarantine-file", "",
+		"Path to a quarantine.yaml listing known-flaky specs with ticket and expiry date")
+	pfs.StringVar(&args.quarantineMode, "quarantine-mode", quarantine.ModeRun,
+		"How to handle quarantined specs: run (failures do not fail the run) or skip")
+	pfs.StringVar(&args.leakCheck, "leak-check", helper.LeakCheckFail,
+		"How to report resources specs did not release, after cleaning them up: fail (the suite) or warn")
+	pfs.StringVar(&args.runID, "run-id", "",
+		"ID of this run, added as the e2e.hyperfleet.io/run-id label of created resources (generated if empty)")
+	pfs.DurationVar(&args.gracePeriod, "grace-period", e2e.DefaultGracePeriod,
+		"How long cleanup may take after SIGINT/SIGTERM before exiting; a second signal exits immediately")
+	pfs.BoolVar(&args.dryRun, "dry-run", false,
+		"Render payload templates, adapter values.yaml files and Helm commands without running specs or touching the environment")
+	pfs.StringVar(&args.apiVersionCheck, "api-version-check", version.APICheckWarn,
+		"What a server API differing from the OpenAPI spec the client was generated from does: warn, fail (the run) or off")
+	pfs.StringVar(&args.httpRecord, "http-record", client.RecordOff,
+		"Record the HTTP interactions of each spec and save those of failed specs to <output-dir>/<spec>/: off, har or jsonl")
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -52,28 +90,62 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Bind flags after config loading (osde2e pattern)
 	pfs := cmd.Flags()
-	_ = viper.BindPFlag(config.Tests.GinkgoLabelFilter, pfs.Lookup("label-filter"))
-	_ = viper.BindPFlag(config.Tests.GinkgoFocus, pfs.Lookup("focus"))
-	_ = viper.BindPFlag(config.Tests.GinkgoSkip, pfs.Lookup("skip"))
-	_ = viper.BindPFlag(config.Tests.JUnitReportPath, pfs.Lookup("junit-report"))
-
-	// Bind parent command flags (api-url, logging flags)
-	parentFlags := cmd.Parent().PersistentFlags()
-	_ = viper.BindPFlag(config.API.URL, parentFlags.Lookup("api-url"))
-	_ = viper.BindPFlag(config.Log.Level, parentFlags.Lookup("log-level"))
-	_ = viper.BindPFlag(config.Log.Format, parentFlags.Lookup("log-format"))
-	_ = viper.BindPFlag(config.Log.Output, parentFlags.Lookup("log-output"))
+	common.BindFilterFlags(cmd)
+	config.BindFlag(config.Tests.JUnitReportPath, pfs.Lookup("junit-report"))
+	config.BindFlag(config.Tests.FlakeAttempts, pfs.Lookup("flake-attempts"))
+	config.BindFlag(config.Tests.Procs, pfs.Lookup("procs"))
+	config.BindFlag(config.Tests.ShardIndex, pfs.Lookup("shard-index"))
+	config.BindFlag(config.Tests.ShardCount, pfs.Lookup("shard-count"))
+	config.BindFlag(config.Tests.Preflight, pfs.Lookup("preflight"))
+	config.BindFlag(config.Tests.SkipLabelValidation, pfs.Lookup("skip-label-validation"))
+	config.BindFlag(config.Tests.QuarantineFile, pfs.Lookup("quarantine-file"))
+	config.BindFlag(config.Tests.QuarantineMode, pfs.Lookup("quarantine-mode"))
+	config.BindFlag(config.Tests.LeakCheck, pfs.Lookup("leak-check"))
+	config.BindFlag(config.Tests.RunID, pfs.Lookup("run-id"))
+	config.BindFlag(config.Tests.GracePeriod, pfs.Lookup("grace-period"))
+	config.BindFlag(config.Tests.DryRun, pfs.Lookup("dry-run"))
+	config.BindFlag(config.Tests.APIVersionCheck, pfs.Lookup("api-version-check"))
+	config.BindFlag(config.Tests.HTTPRecord, pfs.Lookup("http-record"))
+
+	// Bind root command flags (api-url, logging flags)
+	common.BindRootFlags(cmd)
 
 	// Bind test environment variables
-	_ = viper.BindEnv(config.Tests.GinkgoLabelFilter, "GINKGO_LABEL_FILTER")
-	_ = viper.BindEnv(config.Tests.GinkgoFocus, "GINKGO_FOCUS")
-	_ = viper.BindEnv(config.Tests.GinkgoSkip, "GINKGO_SKIP")
-	_ = viper.BindEnv(config.Tests.JUnitReportPath, "JUNIT_REPORT_PATH")
-	_ = viper.BindEnv(config.Tests.SuiteTimeout, "SUITE_TIMEOUT")
+	config.BindEnv(config.Tests.JUnitReportPath, "JUNIT_REPORT_PATH")
+	config.BindEnv(config.Tests.SuiteTimeout, "SUITE_TIMEOUT")
+	config.BindEnv(config.Tests.FlakeAttempts, "FLAKE_ATTEMPTS")
+	config.BindEnv(config.Tests.Procs, "PARALLEL_PROCS")
+	config.BindEnv(config.Tests.ShardIndex, "SHARD_INDEX")
+	config.BindEnv(config.Tests.ShardCount, "SHARD_COUNT")
+	config.BindEnv(config.Tests.Preflight, "PREFLIGHT")
+	config.BindEnv(config.Tests.SkipLabelValidation, "SKIP_LABEL_VALIDATION")
+	config.BindEnv(config.Tests.QuarantineFile, "QUARANTINE_FILE")
+	config.BindEnv(config.Tests.QuarantineMode, "QUARANTINE_MODE")
+	config.BindEnv(config.Tests.LeakCheck, "LEAK_CHECK")
+	config.BindEnv(config.Tests.RunID, "E2E_RUN_ID")
+	config.BindEnv(config.Tests.GracePeriod, "GRACE_PERIOD")
+	config.BindEnv(config.Tests.DryRun, "DRY_RUN")
+	config.BindEnv(config.Tests.APIVersionCheck, "API_VERSION_CHECK")
+	config.BindEnv(config.Tests.HTTPRecord, "HTTP_RECORD")
 
 	// Load and validate config (fast failure before entering Ginkgo)
-	cfg, err := config.Load()
+	cfg, err := config.Resolve()
 	if err != nil {
+		log.Printf("Error resolving config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Chart settings are only required when the selected specs deploy their own adapters, a dry run clones nothing
+	if e2e.DeploysAdapters() && !e2e.DryRunEnabled() {
+		cfg.RequireAdapterDeployment()
+	}
+
+	// A dry run renders the testdata without calling the API
+	if e2e.DryRunEnabled() {
+		cfg.AllowMissingAPIURL()
+	}
+
+	if err := cfg.Validate(); err != nil {
 		log.Printf("Configuration validation failed: %v\n", err)
 		os.Exit(1)
 	}
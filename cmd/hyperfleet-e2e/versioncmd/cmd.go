@@ -0,0 +1,54 @@
+package versioncmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the build information",
+	Long: "Print the version, commit and build date of this binary and the version and sha256 of the\n" +
+		"OpenAPI spec its API client was generated from.",
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var args struct {
+	output string
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&args.output, "output", "o", outputText, "Output format (text, json)")
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	info := version.Get()
+
+	var err error
+	switch args.output {
+	case outputText:
+		_, err = fmt.Fprint(cmd.OutOrStdout(), info)
+	case outputJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(info)
+	default:
+		err = fmt.Errorf("unsupported output format %q (expected %s or %s)", args.output, outputText, outputJSON)
+	}
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
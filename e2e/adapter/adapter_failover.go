@@ -14,7 +14,8 @@ import (
 )
 
 var _ = ginkgo.Describe("[Suite: adapter-failures][negative] Adapter framework can detect and report failures to cluster API endpoints",
-	ginkgo.Label(labels.Tier1),
+	ginkgo.Label(labels.Tier1, labels.AdapterDeployment),
+	ginkgo.Serial,
 	func() {
 		var (
 			h              *helper.Helper
@@ -111,7 +112,7 @@ var _ = ginkgo.Describe("[Suite: adapter-failures][negative] Adapter framework c
 				ginkgo.By("Verify initial status of cluster")
 				// Verify initial conditions are False
 				// Use Eventually to handle async condition propagation
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					cluster, err = h.Client.GetCluster(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster")
 					g.Expect(cluster.Status).NotTo(BeNil(), "cluster status should be present")
@@ -129,7 +130,7 @@ var _ = ginkgo.Describe("[Suite: adapter-failures][negative] Adapter framework c
 
 				ginkgo.By("Verify adapter execution detects failure and reports error")
 				// Wait for adapter to process the cluster and report failure status
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 					g.Expect(statuses.Items).NotTo(BeEmpty(), "adapter should have reported status")
@@ -59,7 +59,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 
 					ginkgo.By("Step 2: Verify ManifestWork (resource bundle) was created on Maestro")
 					// Query Maestro API via HTTP client
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						rb, err := h.GetMaestroClient().FindResourceBundleByClusterID(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to find resource bundle for cluster")
 						resourceBundle = rb
@@ -134,7 +134,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 					ginkgo.By("Step 5: Verify K8s resources created by Maestro agent on target cluster")
 					// Wait for Maestro agent to apply the ManifestWork content
 
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						// Verify Namespace exists, is Active, and has correct labels/annotations
 						ns, err := h.GetNamespace(ctx, namespaceName)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get namespace")
@@ -187,7 +187,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 					}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed())
 
 					ginkgo.By("Step 6: Verify adapter status report to HyperFleet API")
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 
@@ -304,7 +304,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 
 					ginkgo.By("Step 2: Wait for initial ManifestWork creation and capture resource bundle ID")
 					// Query Maestro API to find the resource bundle
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						rb, err := h.GetMaestroClient().FindResourceBundleByClusterID(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to find resource bundle for cluster")
 						resourceBundle = rb
@@ -322,7 +322,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 					ginkgo.By("Step 3: Capture initial adapter status timestamp before skip period")
 					// Capture the initial lastReportTime to verify adapter continues processing
 					var initialReportTime time.Time
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 
@@ -342,7 +342,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 
 					ginkgo.By("Step 4: Verify adapter continued processing during skip period")
 					// We wait up to 3-4 polling cycles to ensure multiple processing cycles occur.
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 
@@ -369,7 +369,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 
 					ginkgo.By("Step 5: Verify Maestro resource version does not change on Skip")
 					// Query the resource bundle again to verify version remains unchanged
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						rb, err := h.GetMaestroClient().FindResourceBundleByClusterID(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to find resource bundle")
 
@@ -400,7 +400,8 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport] Adapter Framework -
 )
 
 var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter Framework - Maestro Transport Negative Scenarios",
-	ginkgo.Label(labels.Tier1),
+	ginkgo.Label(labels.Tier1, labels.AdapterDeployment),
+	ginkgo.Serial,
 	func() {
 		var (
 			h              *helper.Helper
@@ -498,7 +499,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				ginkgo.By("Verify adapter reports failure for unregistered consumer")
 				// Wait for adapter to process the cluster and report failure status
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 					g.Expect(statuses.Items).NotTo(BeEmpty(), "adapter should have reported status")
@@ -564,7 +565,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 				}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed())
 
 				ginkgo.By("Verify no ManifestWork was created by the test adapter on Maestro")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					// Query by cluster ID first to scope to current cluster
 					rbs, err := h.GetMaestroClient().FindAllResourceBundlesByClusterID(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "should be able to query Maestro for resource bundles")
@@ -584,7 +585,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 				}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed())
 
 				ginkgo.By("Verify no K8s resources were created by the test adapter")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					// Check specifically for namespace that would have been created by THIS adapter
 					// Expected namespace name pattern: ${clusterID}-${adapterName}-namespace
 					expectedNamespace := fmt.Sprintf("%s-%s-namespace", clusterID, adapterName)
@@ -638,7 +639,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				// Verify ManifestWork was created by the test adapter despite wrong discovery config
 				ginkgo.By("Verify ManifestWork was created by the test adapter on Maestro")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					// Query by cluster ID first to scope to current cluster
 					rbs, err := h.GetMaestroClient().FindAllResourceBundlesByClusterID(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "should be able to query Maestro for resource bundles")
@@ -660,7 +661,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 				namespaceName := fmt.Sprintf("%s-%s-namespace", clusterID, adapterName)
 				configmapName := fmt.Sprintf("%s-%s-configmap", clusterID, adapterName)
 
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					// Verify namespace exists
 					_, err := h.GetNamespace(ctx, namespaceName)
 					g.Expect(err).NotTo(HaveOccurred(), "namespace should be created by Maestro agent")
@@ -674,7 +675,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				ginkgo.By("Verify adapter reports discovery failure with appropriate error")
 				// Wait for adapter to process the cluster and report discovery failure
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 					g.Expect(statuses.Items).NotTo(BeEmpty(), "adapter should have reported status")
@@ -824,7 +825,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				// Verify ManifestWork was created by the test adapter
 				ginkgo.By("Verify ManifestWork was created by the test adapter on Maestro")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					// Query by cluster ID first to scope to current cluster
 					rbs, err := h.GetMaestroClient().FindAllResourceBundlesByClusterID(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "should be able to query Maestro for resource bundles")
@@ -843,14 +844,14 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				// Verify K8s resources were created
 				ginkgo.By("Verify K8s resources were created by Maestro agent")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					_, err := h.GetNamespace(ctx, namespaceName)
 					g.Expect(err).NotTo(HaveOccurred(), "namespace should be created")
 				}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed())
 
 				ginkgo.By("Verify adapter handles empty discovery with fallback values")
 				// Wait for adapter to process the cluster and report status with fallback values
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 					g.Expect(statuses.Items).NotTo(BeEmpty(), "adapter should have reported status")
@@ -987,7 +988,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				ginkgo.By("Verify ManifestWork was applied successfully by the test adapter in Maestro")
 				// Even though post-action failed, the ManifestWork should exist in Maestro
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					// Query by cluster ID first to scope to current cluster
 					rbs, err := h.GetMaestroClient().FindAllResourceBundlesByClusterID(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "should be able to query Maestro for resource bundles")
@@ -1009,7 +1010,7 @@ var _ = ginkgo.Describe("[Suite: adapter][maestro-transport][negative] Adapter F
 
 				// Verify K8s resources were created
 				ginkgo.By("Verify K8s resources were created by Maestro agent despite API being unreachable")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					_, err := h.GetNamespace(ctx, namespaceName)
 					g.Expect(err).NotTo(HaveOccurred(), "namespace should be created")
 				}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed())
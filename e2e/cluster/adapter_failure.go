@@ -14,7 +14,8 @@ import (
 )
 
 var _ = ginkgo.Describe("[Suite: cluster][negative] Cluster Can Reflect Adapter Failure in Top-Level Status",
-	ginkgo.Label(labels.Tier1, labels.Negative),
+	ginkgo.Label(labels.Tier1, labels.Negative, labels.AdapterDeployment),
+	ginkgo.Serial,
 	func() {
 		var (
 			h              *helper.Helper
@@ -114,7 +115,7 @@ var _ = ginkgo.Describe("[Suite: cluster][negative] Cluster Can Reflect Adapter
 
 				// Step 3: Verify adapter failure is reported via status API
 				ginkgo.By("Verify adapter failure is reported via status API")
-				Eventually(func(g Gomega) {
+				Eventually(ctx, func(g Gomega) {
 					statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 					g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 
@@ -120,7 +120,7 @@ var _ = ginkgo.Describe("[Suite: cluster][concurrent] System can process concurr
 
 				ginkgo.By("Verify all adapter statuses are complete for each cluster")
 				for i, clusterID := range clusterIDs {
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses for cluster %d (%s)", i, clusterID)
 						g.Expect(statuses.Items).NotTo(BeEmpty(), "cluster %d (%s) should have adapter statuses", i, clusterID)
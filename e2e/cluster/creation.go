@@ -60,7 +60,7 @@ var _ = ginkgo.Describe("[Suite: cluster][baseline] Cluster Resource Type Lifecy
                     ginkgo.By("Verify required adapter execution results")
                     // Validate required adapters from config have completed successfully
                     // If an adapter fails, we can identify which specific adapter failed
-                    Eventually(func(g Gomega) {
+                    Eventually(ctx, func(g Gomega) {
                         statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
                         g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
                         g.Expect(statuses.Items).NotTo(BeEmpty(), "at least one adapter should have executed")
@@ -228,7 +228,7 @@ var _ = ginkgo.Describe("[Suite: cluster][baseline] Cluster Resource Type Lifecy
                     // This explicitly tests only adapters that create K8s resources
                     for adapterName, verifier := range adapterResourceVerifiers {
                         ginkgo.By("Verifying Kubernetes resource for adapter: " + adapterName)
-                        Eventually(func() error {
+                        Eventually(ctx, func() error {
                             return verifier()
                         }, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed(),
                             "Kubernetes resource for adapter %s should be created and reach desired state", adapterName)
@@ -262,7 +262,7 @@ var _ = ginkgo.Describe("[Suite: cluster][baseline] Cluster Resource Type Lifecy
                     // Capture cl-deployment's initial waiting state
                     // Poll until cl-deployment appears in the statuses
                     var foundInitialState bool
-                    Eventually(func(g Gomega) {
+                    Eventually(ctx, func(g Gomega) {
                         foundInitialState = false
                         statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
                         g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
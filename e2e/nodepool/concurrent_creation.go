@@ -131,7 +131,7 @@ var _ = ginkgo.Describe("[Suite: nodepool][concurrent] Multiple nodepools can co
 						"hyperfleet.io/cluster-id":  clusterID,
 						"hyperfleet.io/nodepool-id": npID,
 					}
-					Eventually(func() error {
+					Eventually(ctx, func() error {
 						return h.VerifyConfigMap(ctx, clusterID, expectedLabels, nil)
 					}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed(),
 						"nodepool %d (%s) should have its own configmap resource", i, npID)
@@ -140,7 +140,7 @@ var _ = ginkgo.Describe("[Suite: nodepool][concurrent] Multiple nodepools can co
 
 				ginkgo.By("Verify all adapter statuses are complete for each nodepool")
 				for i, npID := range nodepoolIDs {
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						statuses, err := h.Client.GetNodePoolStatuses(ctx, clusterID, npID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get nodepool statuses for nodepool %d (%s)", i, npID)
 						g.Expect(statuses.Items).NotTo(BeEmpty(), "nodepool %d (%s) should have adapter statuses", i, npID)
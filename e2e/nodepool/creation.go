@@ -56,7 +56,7 @@ var _ = ginkgo.Describe("[Suite: nodepool][baseline] NodePool Resource Type Life
 					// Use Eventually to handle race conditions where conditions might not be populated yet
 					initStatusPollInterval := time.Second
 					initCheckTimeout := 3 * time.Second
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 
 						np, err := h.Client.GetNodePool(ctx, clusterID, nodepoolID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get nodepool")
@@ -77,7 +77,7 @@ var _ = ginkgo.Describe("[Suite: nodepool][baseline] NodePool Resource Type Life
 					ginkgo.By("Verify required adapter execution results")
 					// Validate required adapters from config have completed successfully
 					// If an adapter fails, we can identify which specific adapter failed
-					Eventually(func(g Gomega) {
+					Eventually(ctx, func(g Gomega) {
 						statuses, err := h.Client.GetNodePoolStatuses(ctx, clusterID, nodepoolID)
 						g.Expect(err).NotTo(HaveOccurred(), "failed to get nodepool statuses")
 						g.Expect(statuses.Items).NotTo(BeEmpty(), "at least one adapter should have executed")
@@ -228,7 +228,7 @@ var _ = ginkgo.Describe("[Suite: nodepool][baseline] NodePool Resource Type Life
 						}
 
 						ginkgo.By("Verifying Kubernetes resource for adapter: " + adapterName)
-						Eventually(func() error {
+						Eventually(ctx, func() error {
 							return verifier()
 						}, h.Cfg.Timeouts.Adapter.Processing, h.Cfg.Polling.Interval).Should(Succeed(),
 							"Kubernetes resource for adapter %s should be created and reach desired state", adapterName)
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+const (
+	// tokenExpiryLeeway renews tokens this long before they expire, so requests in flight do not carry expired tokens
+	tokenExpiryLeeway = 30 * time.Second
+
+	// tokenFileRefresh is how long a token file that is not a JWT is trusted before it is read again
+	tokenFileRefresh = time.Minute
+)
+
+// TokenSource supplies the bearer token of API requests
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a bearer token that never changes
+type StaticToken string
+
+// Token returns the token
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// cachedToken holds a token until it expires, a zero expiry never expires
+type cachedToken struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+// get returns the cached token, or the token returned by refresh when there is none or it expired
+func (c *cachedToken) get(refresh func() (string, time.Time, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.expiry.IsZero() || c.now().Before(c.expiry.Add(-tokenExpiryLeeway))) {
+		return c.token, nil
+	}
+	token, expiry, err := refresh()
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expiry = token, expiry
+	return token, nil
+}
+
+// tokenFileSource reads the bearer token from a file, e.g. a projected service account token, and reads it
+// again once the token expires so rotated tokens are picked up
+type tokenFileSource struct {
+	path  string
+	cache cachedToken
+}
+
+// NewTokenFileSource returns a token source reading the token from path. JWTs are read again when their exp
+// claim is reached, other tokens every minute.
+func NewTokenFileSource(path string) TokenSource {
+	return &tokenFileSource{path: path, cache: cachedToken{now: time.Now}}
+}
+
+// Token returns the token in the file
+func (s *tokenFileSource) Token(context.Context) (string, error) {
+	return s.cache.get(func() (string, time.Time, error) {
+		data, err := os.ReadFile(s.path)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", time.Time{}, fmt.Errorf("token file %s is empty", s.path)
+		}
+		expiry, ok := jwtExpiry(token)
+		if !ok {
+			expiry = s.cache.now().Add(tokenFileRefresh + tokenExpiryLeeway)
+		}
+		return token, expiry, nil
+	})
+}
+
+// jwtExpiry returns the exp claim of a JWT, without verifying it
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// clientCredentialsSource requests tokens from an OAuth2 token endpoint with the client credentials grant
+type clientCredentialsSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *http.Client
+	cache        cachedToken
+}
+
+// NewClientCredentialsSource returns a token source using the OAuth2 client credentials flow against tokenURL.
+// Tokens are requested again when the expires_in of the last one is reached.
+func NewClientCredentialsSource(tokenURL, clientID, clientSecret string, scopes []string, httpClient *http.Client) TokenSource {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &clientCredentialsSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		httpClient:   httpClient,
+		cache:        cachedToken{now: time.Now},
+	}
+}
+
+// Token returns the current access token, requesting a new one when it expired
+func (s *clientCredentialsSource) Token(ctx context.Context) (string, error) {
+	return s.cache.get(func() (string, time.Time, error) {
+		return s.requestToken(ctx)
+	})
+}
+
+// requestToken exchanges the client credentials for an access token
+func (s *clientCredentialsSource) requestToken(ctx context.Context) (string, time.Time, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+	}
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("unexpected status code %d for token request: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if result.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("token response has no access_token")
+	}
+
+	var expiry time.Time
+	if result.ExpiresIn > 0 {
+		expiry = s.cache.now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return result.AccessToken, expiry, nil
+}
+
+// NewTokenSource returns the token source of the configured auth mode, nil when requests are not authenticated.
+// httpClient is used for token requests, a default client when nil.
+func NewTokenSource(auth config.AuthConfig, httpClient *http.Client) (TokenSource, error) {
+	switch auth.Mode {
+	case "", config.AuthModeNone:
+		return nil, nil
+	case config.AuthModeToken:
+		return StaticToken(auth.Token), nil
+	case config.AuthModeTokenFile:
+		return NewTokenFileSource(auth.TokenFile), nil
+	case config.AuthModeClientCredentials:
+		return NewClientCredentialsSource(auth.TokenURL, auth.ClientID, auth.ClientSecret, auth.Scopes, httpClient), nil
+	}
+	return nil, fmt.Errorf("unsupported auth mode %q", auth.Mode)
+}
+
+// BearerAuth returns a request editor setting the Authorization header to a token of source.
+// It is an openapi.RequestEditorFn and can be passed to maestro.WithRequestEditorFn as well.
+func BearerAuth(source TokenSource) openapi.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		token, err := source.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get API token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// WithAuth authenticates the requests of a HyperFleet client as configured, requesting tokens with httpClient
+func WithAuth(auth config.AuthConfig, httpClient *http.Client) openapi.ClientOption {
+	return func(c *openapi.Client) error {
+		source, err := NewTokenSource(auth, httpClient)
+		if err != nil || source == nil {
+			return err
+		}
+		return openapi.WithRequestEditorFn(BearerAuth(source))(c)
+	}
+}
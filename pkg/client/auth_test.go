@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+// tokenServer is an OAuth2 token endpoint issuing token-1, token-2, ... valid for expiresIn seconds
+func tokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_id") != "e2e" ||
+			r.PostForm.Get("client_secret") != "s3cret" {
+			http.Error(w, `{"error": "invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.PostForm.Get("scope") != "openid api.hyperfleet" {
+			http.Error(w, `{"error": "invalid_scope"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d}`, issued.Add(1), expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func TestClientCredentialsSource(t *testing.T) {
+	server, issued := tokenServer(t, 300)
+	source := NewClientCredentialsSource(server.URL, "e2e", "s3cret", []string{"openid", "api.hyperfleet"}, nil).(*clientCredentialsSource)
+	now := time.Now()
+	source.cache.now = func() time.Time { return now }
+
+	for range 2 {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token() error = %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("Token() = %q, want the cached token-1", token)
+		}
+	}
+
+	// Renewed within the leeway before expiry
+	now = now.Add(300*time.Second - tokenExpiryLeeway)
+	if token, err := source.Token(context.Background()); err != nil || token != "token-2" {
+		t.Errorf("Token() after expiry = %q, %v, want token-2", token, err)
+	}
+	if issued.Load() != 2 {
+		t.Errorf("token endpoint called %d times, want 2", issued.Load())
+	}
+}
+
+func TestClientCredentialsSourceRejected(t *testing.T) {
+	server, _ := tokenServer(t, 300)
+	source := NewClientCredentialsSource(server.URL, "e2e", "wrong", []string{"openid", "api.hyperfleet"}, nil)
+
+	if _, err := source.Token(context.Background()); err == nil {
+		t.Error("Token() with a wrong secret succeeded, want the token endpoint error")
+	}
+}
+
+// jwt returns an unsigned JWT expiring at exp
+func jwt(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub": "e2e", "exp": %d}`, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".sig"
+}
+
+func TestTokenFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	now := time.Now()
+	first := jwt(now.Add(time.Hour))
+	if err := os.WriteFile(path, []byte(first+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := NewTokenFileSource(path).(*tokenFileSource)
+	source.cache.now = func() time.Time { return now }
+
+	if token, err := source.Token(context.Background()); err != nil || token != first {
+		t.Fatalf("Token() = %q, %v, want the file content without the newline", token, err)
+	}
+
+	// A rotated file is only read again once the token in use expires
+	second := jwt(now.Add(2 * time.Hour))
+	if err := os.WriteFile(path, []byte(second), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := source.Token(context.Background()); token != first {
+		t.Errorf("Token() before expiry = %q, want the cached token", token)
+	}
+	now = now.Add(time.Hour)
+	if token, _ := source.Token(context.Background()); token != second {
+		t.Errorf("Token() after expiry = %q, want the rotated token", token)
+	}
+}
+
+func TestTokenFileSourceOpaqueToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("opaque-1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := NewTokenFileSource(path).(*tokenFileSource)
+	now := time.Now()
+	source.cache.now = func() time.Time { return now }
+
+	if token, _ := source.Token(context.Background()); token != "opaque-1" {
+		t.Fatalf("Token() = %q, want opaque-1", token)
+	}
+	if err := os.WriteFile(path, []byte("opaque-2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(tokenFileRefresh)
+	if token, _ := source.Token(context.Background()); token != "opaque-2" {
+		t.Errorf("Token() after %s = %q, want the file read again", tokenFileRefresh, token)
+	}
+}
+
+func TestWithAuth(t *testing.T) {
+	tokens, _ := tokenServer(t, 300)
+	var authorization string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte("openapi: 3.0.0\n"))
+	}))
+	defer api.Close()
+
+	tests := []struct {
+		name string
+		auth config.AuthConfig
+		want string
+	}{
+		{name: "none", auth: config.AuthConfig{Mode: config.AuthModeNone}, want: ""},
+		{name: "token", auth: config.AuthConfig{Mode: config.AuthModeToken, Token: "static"}, want: "Bearer static"},
+		{
+			name: "client credentials",
+			auth: config.AuthConfig{
+				Mode: config.AuthModeClientCredentials, TokenURL: tokens.URL,
+				ClientID: "e2e", ClientSecret: "s3cret", Scopes: []string{"openid", "api.hyperfleet"},
+			},
+			want: "Bearer token-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl, err := NewHyperFleetClient(api.URL, nil, WithAuth(tt.auth, nil))
+			if err != nil {
+				t.Fatalf("NewHyperFleetClient() error = %v", err)
+			}
+			if _, err := cl.GetOpenAPISpec(context.Background()); err != nil {
+				t.Fatalf("GetOpenAPISpec() error = %v", err)
+			}
+			if authorization != tt.want {
+				t.Errorf("Authorization = %q, want %q", authorization, tt.want)
+			}
+		})
+	}
+}
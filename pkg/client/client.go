@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
 )
@@ -14,15 +13,25 @@ import (
 // convenience methods and better error handling for E2E tests.
 type HyperFleetClient struct {
 	*openapi.Client
+
+	// RunID is the e2e.hyperfleet.io/run-id label of created resources, omitted when empty
+	RunID string
+	// SpecName returns the full text of the running spec, for the e2e.hyperfleet.io/spec label of created resources
+	SpecName func() string
+
+	// OnClusterCreated and OnNodePoolCreated, if set, are called for every resource created through the client
+	OnClusterCreated  func(clusterID string)
+	OnNodePoolCreated func(clusterID, nodepoolID string)
 }
 
 // NewHyperFleetClient creates a new HyperFleet API client.
-func NewHyperFleetClient(baseURL string, httpClient *http.Client) (*HyperFleetClient, error) {
+// Options such as WithAuth are applied to the generated client.
+func NewHyperFleetClient(baseURL string, httpClient *http.Client, opts ...openapi.ClientOption) (*HyperFleetClient, error) {
 	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 30 * time.Second}
+		httpClient = &http.Client{Timeout: DefaultTimeout}
 	}
 
-	client, err := openapi.NewClient(baseURL, openapi.WithHTTPClient(httpClient))
+	client, err := openapi.NewClient(baseURL, append([]openapi.ClientOption{openapi.WithHTTPClient(httpClient)}, opts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
@@ -34,6 +43,7 @@ func NewHyperFleetClient(baseURL string, httpClient *http.Client) (*HyperFleetCl
 
 // handleHTTPResponse is a generic helper for processing HTTP responses.
 // It handles status code validation, response body decoding, and error formatting.
+// An unexpected status code is returned as an *APIError.
 func handleHTTPResponse[T any](resp *http.Response, expectedStatus int, action string) (*T, error) {
 	defer func() { _ = resp.Body.Close() }()
 
@@ -43,8 +53,7 @@ func handleHTTPResponse[T any](resp *http.Response, expectedStatus int, action s
 			return nil, fmt.Errorf("unexpected status code %d for %s (failed to read response body: %w)",
 				resp.StatusCode, action, err)
 		}
-		return nil, fmt.Errorf("unexpected status code %d for %s: %s",
-			resp.StatusCode, action, string(body))
+		return nil, newAPIError(resp, body, action)
 	}
 
 	var result T
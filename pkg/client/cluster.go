@@ -12,6 +12,7 @@ import (
 // CreateCluster creates a new cluster and returns the created cluster object.
 func (c *HyperFleetClient) CreateCluster(ctx context.Context, req openapi.ClusterCreateRequest) (*openapi.Cluster, error) {
 	logger.Info("creating cluster", "name", req.Name)
+	req.Labels = c.withTrackingLabels(req.Labels)
 
 	resp, err := c.PostCluster(ctx, req)
 	if err != nil {
@@ -25,6 +26,9 @@ func (c *HyperFleetClient) CreateCluster(ctx context.Context, req openapi.Cluste
 	}
 
 	logger.Info("cluster created", "cluster_id", *cluster.Id, "name", req.Name)
+	if c.OnClusterCreated != nil {
+		c.OnClusterCreated(*cluster.Id)
+	}
 	return cluster, nil
 }
 
@@ -8,6 +8,17 @@ const (
 	KeyGeneration = "hyperfleet.io/generation"
 )
 
+// E2E test framework keys used to mark resources created by test runs
+const (
+	KeyE2EManagedBy        = "e2e.hyperfleet.io/managed-by"
+	ManagedByTestFramework = "test-framework"
+
+	// Labels added to every cluster and nodepool created through HyperFleetClient
+	KeyE2ERunID     = "e2e.hyperfleet.io/run-id"
+	KeyE2ESpec      = "e2e.hyperfleet.io/spec"
+	KeyE2ECreatedAt = "e2e.hyperfleet.io/created-at"
+)
+
 // Condition types used by adapters
 const (
 	ConditionTypeApplied   = "Applied"   // Resources created successfully
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader is the response header carrying the ID the API logs a request under
+const RequestIDHeader = "X-Request-Id"
+
+// APIError is returned when the HyperFleet API answers with an unexpected status code.
+// Use errors.As to inspect it, or the helper.BeAPIErrorWithStatus and helper.HaveFieldViolation matchers.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Action describes the failed call, e.g. "create cluster"
+	Action string
+
+	// Code is the machine-readable error code, e.g. HYPERFLEET-VAL-001
+	Code string
+	// Reason is the human-readable summary of the error
+	Reason string
+	// Details is the longer explanation of the error, if any
+	Details string
+	// FieldViolations lists the request fields that failed validation
+	FieldViolations []FieldViolation
+
+	// RequestID identifies the request in the API logs
+	RequestID string
+	// Body is the raw response body
+	Body string
+}
+
+// FieldViolation is a request field rejected by the API
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error returns the status, the parsed error body and the request ID, or the raw body when it was not
+// an API error document
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "unexpected status code %d for %s", e.StatusCode, e.Action)
+
+	switch {
+	case e.Code == "" && e.Reason == "" && e.Details == "" && len(e.FieldViolations) == 0:
+		if e.Body != "" {
+			fmt.Fprintf(&b, ": %s", e.Body)
+		}
+	default:
+		if e.Code != "" {
+			fmt.Fprintf(&b, ": %s", e.Code)
+		}
+		if e.Reason != "" {
+			fmt.Fprintf(&b, ": %s", e.Reason)
+		}
+		if e.Details != "" {
+			fmt.Fprintf(&b, ": %s", e.Details)
+		}
+		for _, violation := range e.FieldViolations {
+			fmt.Fprintf(&b, "; %s: %s", violation.Field, violation.Message)
+		}
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// FieldViolation returns the violation of a request field, nil when the field was not rejected
+func (e *APIError) FieldViolation(field string) *FieldViolation {
+	for i := range e.FieldViolations {
+		if e.FieldViolations[i].Field == field {
+			return &e.FieldViolations[i]
+		}
+	}
+	return nil
+}
+
+// apiErrorBody is the error document of the API. Both the reason/details format and RFC 9457 problem
+// details (title, detail, errors) are accepted.
+type apiErrorBody struct {
+	Code        string          `json:"code"`
+	Reason      string          `json:"reason"`
+	Title       string          `json:"title"`
+	Detail      string          `json:"detail"`
+	Details     json.RawMessage `json:"details"` // a string or a list of field violations
+	Errors      []violationBody `json:"errors"`
+	OperationID string          `json:"operation_id"`
+	TraceID     string          `json:"trace_id"`
+}
+
+// violationBody is a field violation as the API sends it, with the message in message or error
+type violationBody struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Error   string `json:"error"`
+}
+
+// newAPIError builds the error for a response with an unexpected status code from its body
+func newAPIError(resp *http.Response, body []byte, action string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Action:     action,
+		RequestID:  resp.Header.Get(RequestIDHeader),
+		Body:       string(body),
+	}
+
+	var doc apiErrorBody
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return apiErr
+	}
+
+	apiErr.Code = doc.Code
+	apiErr.Reason = cmp.Or(doc.Reason, doc.Title)
+	apiErr.Details = doc.Detail
+
+	violations := doc.Errors
+	if len(doc.Details) > 0 {
+		var details string
+		if err := json.Unmarshal(doc.Details, &details); err == nil {
+			apiErr.Details = cmp.Or(apiErr.Details, details)
+		} else {
+			var list []violationBody
+			if err := json.Unmarshal(doc.Details, &list); err == nil {
+				violations = append(violations, list...)
+			}
+		}
+	}
+	for _, violation := range violations {
+		apiErr.FieldViolations = append(apiErr.FieldViolations, FieldViolation{
+			Field:   violation.Field,
+			Message: cmp.Or(violation.Message, violation.Error),
+		})
+	}
+
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = cmp.Or(doc.OperationID, doc.TraceID)
+	}
+	return apiErr
+}
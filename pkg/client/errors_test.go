@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		requestID string
+		body      string
+		want      APIError
+		wantMsg   string
+	}{
+		{
+			name:      "reason and details",
+			status:    http.StatusBadRequest,
+			requestID: "req-1",
+			body: `{"kind": "Error", "code": "HYPERFLEET-VAL-001", "reason": "Validation failed",
+				"details": [{"field": "name", "error": "must be at most 63 characters"}]}`,
+			want: APIError{
+				StatusCode: http.StatusBadRequest, Action: "create cluster", Code: "HYPERFLEET-VAL-001",
+				Reason: "Validation failed", RequestID: "req-1",
+				FieldViolations: []FieldViolation{{Field: "name", Message: "must be at most 63 characters"}},
+			},
+			wantMsg: "unexpected status code 400 for create cluster: HYPERFLEET-VAL-001: Validation failed; " +
+				"name: must be at most 63 characters (request ID req-1)",
+		},
+		{
+			name:   "problem details",
+			status: http.StatusBadRequest,
+			body: `{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "invalid spec",
+				"code": "HYPERFLEET-VAL-002", "trace_id": "trace-1",
+				"errors": [{"field": "spec.region", "message": "unsupported region"}]}`,
+			want: APIError{
+				StatusCode: http.StatusBadRequest, Action: "create cluster", Code: "HYPERFLEET-VAL-002",
+				Reason: "Bad Request", Details: "invalid spec", RequestID: "trace-1",
+				FieldViolations: []FieldViolation{{Field: "spec.region", Message: "unsupported region"}},
+			},
+			wantMsg: "unexpected status code 400 for create cluster: HYPERFLEET-VAL-002: Bad Request: invalid spec; " +
+				"spec.region: unsupported region (request ID trace-1)",
+		},
+		{
+			name:   "string details",
+			status: http.StatusConflict,
+			body:   `{"code": "HYPERFLEET-CNF-001", "reason": "Conflict", "details": "cluster exists", "operation_id": "op-1"}`,
+			want: APIError{
+				StatusCode: http.StatusConflict, Action: "create cluster", Code: "HYPERFLEET-CNF-001",
+				Reason: "Conflict", Details: "cluster exists", RequestID: "op-1",
+			},
+			wantMsg: "unexpected status code 409 for create cluster: HYPERFLEET-CNF-001: Conflict: cluster exists (request ID op-1)",
+		},
+		{
+			name:    "not JSON",
+			status:  http.StatusBadGateway,
+			body:    "upstream connect error",
+			want:    APIError{StatusCode: http.StatusBadGateway, Action: "create cluster"},
+			wantMsg: "unexpected status code 502 for create cluster: upstream connect error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.requestID != "" {
+					w.Header().Set(RequestIDHeader, tt.requestID)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cl, err := NewHyperFleetClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = cl.CreateCluster(context.Background(), openapi.ClusterCreateRequest{Name: "c1"})
+
+			var apiErr *APIError
+			if !errors.As(fmt.Errorf("wrapped: %w", err), &apiErr) {
+				t.Fatalf("CreateCluster() error = %v, want an *APIError", err)
+			}
+			tt.want.Body = tt.body
+			if !reflect.DeepEqual(*apiErr, tt.want) {
+				t.Errorf("APIError = %+v, want %+v", *apiErr, tt.want)
+			}
+			if got := apiErr.Error(); got != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", got, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestAPIErrorFieldViolation(t *testing.T) {
+	apiErr := &APIError{FieldViolations: []FieldViolation{{Field: "name", Message: "required"}}}
+	if v := apiErr.FieldViolation("name"); v == nil || v.Message != "required" {
+		t.Errorf("FieldViolation(name) = %v, want the violation", v)
+	}
+	if v := apiErr.FieldViolation("spec"); v != nil {
+		t.Errorf("FieldViolation(spec) = %v, want nil", v)
+	}
+}
@@ -135,6 +135,15 @@ func (c *Client) FindNamespacesByPrefix(ctx context.Context, prefix string) ([]s
 	return matchingNamespaces, nil
 }
 
+// FetchNamespacesByLabelSelector lists all namespaces matching the given label selector expression
+func (c *Client) FetchNamespacesByLabelSelector(ctx context.Context, selector string) ([]corev1.Namespace, error) {
+	namespaces, err := c.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces with selector %s: %w", selector, err)
+	}
+	return namespaces.Items, nil
+}
+
 // FetchConfigMap gets a configmap by name in the specified namespace
 func (c *Client) FetchConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
 	cm, err := c.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CreatedAtFormat is the format of the e2e.hyperfleet.io/created-at label, label values cannot contain colons
+const CreatedAtFormat = "20060102T150405Z"
+
+// maxLabelValueLength is the Kubernetes label value length limit, labels may be copied to downstream objects
+const maxLabelValueLength = 63
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// withTrackingLabels returns the labels of a create request merged with the managed-by, run ID, spec and creation
+// time labels, so the resource and the objects adapters derive from it can be traced back to a run and spec.
+// The tracking labels take precedence over payload labels with the same key.
+func (c *HyperFleetClient) withTrackingLabels(labels *map[string]string) *map[string]string {
+	merged := make(map[string]string)
+	if labels != nil {
+		for key, value := range *labels {
+			merged[key] = value
+		}
+	}
+
+	merged[KeyE2EManagedBy] = ManagedByTestFramework
+	merged[KeyE2ECreatedAt] = time.Now().UTC().Format(CreatedAtFormat)
+	if c.RunID != "" {
+		merged[KeyE2ERunID] = c.RunID
+	}
+	if c.SpecName != nil {
+		if spec := c.SpecName(); spec != "" {
+			merged[KeyE2ESpec] = SpecLabelValue(spec)
+		}
+	}
+	return &merged
+}
+
+// SpecLabelValue turns a spec full text into a valid label value: a readable slug of the text, truncated,
+// followed by a hash of the full text so truncated texts stay distinct
+func SpecLabelValue(specText string) string {
+	sum := sha256.Sum256([]byte(specText))
+	hash := hex.EncodeToString(sum[:4])
+
+	slug := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(specText), "-"), "-")
+	if maxSlug := maxLabelValueLength - len(hash) - 1; len(slug) > maxSlug {
+		slug = strings.TrimRight(slug[:maxSlug], "-")
+	}
+	if slug == "" {
+		return hash
+	}
+	return slug + "-" + hash
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestSpecLabelValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		specText string
+		want     string
+	}{
+		{
+			name:     "short text",
+			specText: "[Suite: cluster] Creation should reach Ready",
+			want:     "suite-cluster-creation-should-reach-ready-",
+		},
+		{
+			name:     "long text is truncated",
+			specText: "[Suite: adapter] Adapter Framework - Failover should detect invalid K8s resource and report failure",
+			want:     "suite-adapter-adapter-framework-failover-should-detect-",
+		},
+		{name: "no alphanumeric characters", specText: "[ ]", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SpecLabelValue(tt.specText)
+			if !strings.HasPrefix(got, tt.want) || len(got) != len(tt.want)+8 {
+				t.Errorf("SpecLabelValue() = %q, want %q followed by an 8-character hash", got, tt.want)
+			}
+			if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+				t.Errorf("SpecLabelValue() = %q is not a valid label value: %v", got, errs)
+			}
+		})
+	}
+
+	if SpecLabelValue("spec a") == SpecLabelValue("spec-a") {
+		t.Error("SpecLabelValue() is the same for texts with the same slug")
+	}
+}
+
+func TestWithTrackingLabels(t *testing.T) {
+	c := &HyperFleetClient{
+		RunID:    "20261016-200719-3f9a2c",
+		SpecName: func() string { return "creates a cluster" },
+	}
+	payload := map[string]string{"team": "platform", KeyE2ERunID: "from-payload"}
+
+	labels := *c.withTrackingLabels(&payload)
+	if labels["team"] != "platform" {
+		t.Errorf("payload label team = %q, want it kept", labels["team"])
+	}
+	if labels[KeyE2ERunID] != "20261016-200719-3f9a2c" {
+		t.Errorf("run ID label = %q, want the client run ID over the payload one", labels[KeyE2ERunID])
+	}
+	if labels[KeyE2ESpec] != SpecLabelValue("creates a cluster") {
+		t.Errorf("spec label = %q", labels[KeyE2ESpec])
+	}
+	if labels[KeyE2EManagedBy] != ManagedByTestFramework {
+		t.Errorf("managed-by label = %q, want %q", labels[KeyE2EManagedBy], ManagedByTestFramework)
+	}
+	if errs := validation.IsValidLabelValue(labels[KeyE2ECreatedAt]); labels[KeyE2ECreatedAt] == "" || len(errs) > 0 {
+		t.Errorf("created-at label = %q is not a valid label value: %v", labels[KeyE2ECreatedAt], errs)
+	}
+	if payload[KeyE2ERunID] != "from-payload" {
+		t.Error("withTrackingLabels() modified the payload labels")
+	}
+
+	// Outside of specs and without a run ID only the managed-by label and the creation time are added
+	labels = *(&HyperFleetClient{}).withTrackingLabels(nil)
+	if len(labels) != 2 || labels[KeyE2EManagedBy] == "" || labels[KeyE2ECreatedAt] == "" {
+		t.Errorf("labels without run ID and spec = %v, want only %s and %s", labels, KeyE2EManagedBy, KeyE2ECreatedAt)
+	}
+}
@@ -24,8 +24,22 @@ const (
 
 // Client provides methods to interact with the Maestro API
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL        string
+	httpClient     *http.Client
+	requestEditors []func(ctx context.Context, req *http.Request) error
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are sent with
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithRequestEditorFn adds a function applied to every request before it is sent, e.g. client.BearerAuth
+func WithRequestEditorFn(fn func(ctx context.Context, req *http.Request) error) Option {
+	return func(c *Client) { c.requestEditors = append(c.requestEditors, fn) }
 }
 
 // NewClient creates a new Maestro API client
@@ -33,7 +47,7 @@ type Client struct {
 //  1. MAESTRO_URL environment variable
 //  2. Auto-discovery from Kubernetes cluster (if available)
 //  3. Default in-cluster service URL
-func NewClient(baseURL string) *Client {
+func NewClient(baseURL string, opts ...Option) *Client {
 	if baseURL == "" {
 		baseURL = os.Getenv("MAESTRO_URL")
 		if baseURL == "" {
@@ -46,12 +60,26 @@ func NewClient(baseURL string) *Client {
 		}
 	}
 
-	return &Client{
+	c := &Client{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// do applies the request editors and sends the request
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for _, edit := range c.requestEditors {
+		if err := edit(req.Context(), req); err != nil {
+			return nil, err
+		}
+	}
+	return c.httpClient.Do(req)
 }
 
 // GetResourceBundles retrieves all resource bundles from Maestro
@@ -62,7 +90,7 @@ func (c *Client) GetResourceBundles(ctx context.Context) (*ResourceBundleList, e
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -91,7 +119,7 @@ func (c *Client) GetResourceBundle(ctx context.Context, id string) (*ResourceBun
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -120,7 +148,7 @@ func (c *Client) DeleteResourceBundle(ctx context.Context, id string) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -151,7 +179,7 @@ func (c *Client) FindResourceBundleByClusterID(ctx context.Context, clusterID st
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -199,7 +227,7 @@ func (c *Client) FindAllResourceBundlesByClusterID(ctx context.Context, clusterI
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -244,7 +272,7 @@ func (c *Client) FindResourceBundlesByAdapterName(ctx context.Context, adapterNa
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -283,7 +311,7 @@ func (c *Client) ListConsumers(ctx context.Context) ([]string, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
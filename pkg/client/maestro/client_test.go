@@ -0,0 +1,27 @@
+package maestro
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+)
+
+func TestWithRequestEditorFn(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, WithRequestEditorFn(client.BearerAuth(client.StaticToken("maestro-token"))))
+	if _, err := c.GetResourceBundles(context.Background()); err != nil {
+		t.Fatalf("GetResourceBundles() error = %v", err)
+	}
+	if authorization != "Bearer maestro-token" {
+		t.Errorf("Authorization = %q, want the bearer token", authorization)
+	}
+}
@@ -1,5 +1,7 @@
 package maestro
 
+import "time"
+
 // ResourceBundleList represents the response from Maestro API listing resource bundles
 type ResourceBundleList struct {
 	Items []ResourceBundle `json:"items"`
@@ -14,6 +16,7 @@ type ResourceBundle struct {
 	Manifests       []Manifest        `json:"manifests"`
 	ManifestConfigs []ManifestConfig  `json:"manifest_configs"`
 	DeleteOption    *DeleteOption     `json:"delete_option,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
 }
 
 // DeleteOption represents the deletion options for a resource bundle
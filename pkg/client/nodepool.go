@@ -12,6 +12,7 @@ import (
 // CreateNodePool creates a new nodepool for the specified cluster.
 func (c *HyperFleetClient) CreateNodePool(ctx context.Context, clusterID string, req openapi.NodePoolCreateRequest) (*openapi.NodePool, error) {
 	logger.Info("creating nodepool", "cluster_id", clusterID, "name", req.Name)
+	req.Labels = c.withTrackingLabels(req.Labels)
 
 	resp, err := c.Client.CreateNodePool(ctx, clusterID, req)
 	if err != nil {
@@ -25,6 +26,9 @@ func (c *HyperFleetClient) CreateNodePool(ctx context.Context, clusterID string,
 	}
 
 	logger.Info("nodepool created", "cluster_id", clusterID, "nodepool_id", *nodepool.Id, "name", req.Name)
+	if c.OnNodePoolCreated != nil {
+		c.OnNodePoolCreated(clusterID, *nodepool.Id)
+	}
 	return nodepool, nil
 }
 
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OpenAPIPath is where the HyperFleet API serves its OpenAPI document, relative to the API URL
+const OpenAPIPath = "api/hyperfleet/v1/openapi"
+
+// GetOpenAPISpec retrieves the OpenAPI document served by the API.
+func (c *HyperFleetClient) GetOpenAPISpec(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.Server, "/")+"/"+OpenAPIPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI request: %w", err)
+	}
+	for _, edit := range c.RequestEditors {
+		if err := edit(ctx, req); err != nil {
+			return nil, fmt.Errorf("failed to prepare OpenAPI request: %w", err)
+		}
+	}
+
+	resp, err := c.Client.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAPI spec: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, body, "get OpenAPI spec")
+	}
+	return body, nil
+}
@@ -55,7 +55,8 @@ func renderTemplate(templateContent []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func loadPayloadFromFile[T any](payloadPath string) (*T, error) {
+// RenderPayloadFile reads a payload template and renders it the way the CreateXFromPayload methods do
+func RenderPayloadFile(payloadPath string) ([]byte, error) {
 	// #nosec G304 -- payloadPath is a user-provided test data file path
 	data, err := os.ReadFile(payloadPath)
 	if err != nil {
@@ -67,6 +68,14 @@ func loadPayloadFromFile[T any](payloadPath string) (*T, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to render template: %w", err)
 	}
+	return renderedData, nil
+}
+
+func loadPayloadFromFile[T any](payloadPath string) (*T, error) {
+	renderedData, err := RenderPayloadFile(payloadPath)
+	if err != nil {
+		return nil, err
+	}
 
 	var payload T
 	if err := json.Unmarshal(renderedData, &payload); err != nil {
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+// Recording formats of the HTTP interactions of failed specs
+const (
+	RecordOff       = "off"
+	RecordHAR       = "har"   // HTTP Archive 1.2, opens in browser developer tools
+	RecordJSONLines = "jsonl" // one HAR entry per line
+)
+
+// ValidateRecordFormat checks an HTTP recording format
+func ValidateRecordFormat(format string) error {
+	switch format {
+	case RecordOff, RecordHAR, RecordJSONLines:
+		return nil
+	}
+	return fmt.Errorf("invalid HTTP recording format %q (must be %s, %s or %s)", format, RecordOff, RecordHAR, RecordJSONLines)
+}
+
+// maxRecordedBody bounds the bytes of a request or response body kept in a recording
+const maxRecordedBody = 1 << 20
+
+// redactedHeaders are recorded with their value replaced by config.RedactedPlaceholder
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// Recorder captures the requests sent through its transports and the responses to them, so the HTTP
+// interactions of a spec can be saved when it fails
+type Recorder struct {
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+// NewRecorder returns an empty recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Transport wraps next so that every request sent through it is recorded
+func (r *Recorder) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{next: next, recorder: r}
+}
+
+// Reset discards the recorded interactions
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// Len returns the number of recorded interactions
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// Write writes the recorded interactions in a recording format
+func (r *Recorder) Write(w io.Writer, format string) error {
+	r.mu.Lock()
+	entries := append([]harEntry{}, r.entries...)
+	r.mu.Unlock()
+
+	switch format {
+	case RecordHAR:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(harFile{Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "hyperfleet-e2e", Version: version.Get().Version},
+			Entries: entries,
+		}})
+	case RecordJSONLines:
+		encoder := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ValidateRecordFormat(format)
+}
+
+// add appends an interaction to the recording
+func (r *Recorder) add(entry harEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+// recordingTransport records the requests it forwards to next
+type recordingTransport struct {
+	next     http.RoundTripper
+	recorder *Recorder
+}
+
+// RoundTrip forwards the request and records it with its response or error
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	// RoundTrippers must not modify the request, a body read here is forwarded in a copy
+	if body != nil && req.GetBody == nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	entry := harEntry{
+		StartedDateTime: time.Now().UTC().Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.Redacted(),
+			HTTPVersion: req.Proto,
+			Headers:     harHeaders(req.Header),
+			QueryString: harQueryString(req),
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(body),
+		},
+		Cache: struct{}{},
+	}
+	if body != nil {
+		entry.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: recordedText(body)}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		entry.Time = milliseconds(time.Since(start))
+		entry.Timings = harTimings{Wait: entry.Time}
+		entry.Response = harResponse{Headers: []harNameValue{}, Cookies: []harNameValue{}, HeadersSize: -1, BodySize: -1}
+		entry.Error = err.Error()
+		t.recorder.add(entry)
+		return nil, err
+	}
+
+	// The caller reads the body from the recorded copy
+	data, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	entry.Time = milliseconds(time.Since(start))
+	entry.Timings = harTimings{Wait: entry.Time}
+	entry.Response = harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Headers:     harHeaders(resp.Header),
+		Cookies:     []harNameValue{},
+		Content: harContent{
+			Size:     len(data),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     recordedText(data),
+		},
+		HeadersSize: -1,
+		BodySize:    len(data),
+	}
+	if readErr != nil {
+		entry.Error = fmt.Sprintf("failed to read response body: %v", readErr)
+	}
+	t.recorder.add(entry)
+	return resp, nil
+}
+
+// requestBody returns a copy of the request body, nil when there is none
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = body.Close() }()
+		return io.ReadAll(body)
+	}
+	defer func() { _ = req.Body.Close() }()
+	return io.ReadAll(req.Body)
+}
+
+// recordedText returns a body as recorded, truncated to maxRecordedBody bytes
+func recordedText(body []byte) string {
+	if len(body) > maxRecordedBody {
+		return string(body[:maxRecordedBody]) + fmt.Sprintf("... (truncated, %d bytes)", len(body))
+	}
+	return string(body)
+}
+
+// harHeaders lists headers sorted by name, redacting credentials
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			if redactedHeaders[http.CanonicalHeaderKey(name)] {
+				value = config.RedactedPlaceholder
+			}
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return headers
+}
+
+// harQueryString lists the query parameters of the request URL sorted by name
+func harQueryString(req *http.Request) []harNameValue {
+	params := []harNameValue{}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			params = append(params, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
+}
+
+// milliseconds converts a duration to the fractional milliseconds HAR timings use
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// HAR 1.2 types, see http://www.softwareishard.com/blog/har-12-spec/
+// Fields the recorder cannot fill are omitted or set to -1 as the spec allows
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Error           string      `json:"_error,omitempty"` // transport error, the response is empty
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+func TestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "c1", "echo": ` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder()
+	cl, err := NewHyperFleetClient(server.URL, &http.Client{Transport: recorder.Transport(nil)},
+		WithAuth(config.AuthConfig{Mode: config.AuthModeToken, Token: "s3cret-token"}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/api/hyperfleet/v1/clusters?dry=1",
+		strings.NewReader(`{"name": "c1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, edit := range cl.RequestEditors {
+		if err := edit(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := cl.Client.Client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if string(body) != `{"id": "c1", "echo": {"name": "c1"}}` {
+		t.Errorf("caller read body %q, want the full response", body)
+	}
+
+	var har harFile
+	var out bytes.Buffer
+	if err := recorder.Write(&out, RecordHAR); err != nil {
+		t.Fatalf("Write(har) error = %v", err)
+	}
+	if err := json.Unmarshal(out.Bytes(), &har); err != nil {
+		t.Fatalf("recording is not JSON: %v", err)
+	}
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 1 {
+		t.Fatalf("HAR log = %+v, want version 1.2 with one entry", har.Log)
+	}
+	entry := har.Log.Entries[0]
+	if entry.Request.Method != http.MethodPost || entry.Request.PostData == nil || entry.Request.PostData.Text != `{"name": "c1"}` {
+		t.Errorf("request = %+v, want the POST with its body", entry.Request)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0] != (harNameValue{Name: "dry", Value: "1"}) {
+		t.Errorf("query string = %v, want dry=1", entry.Request.QueryString)
+	}
+	if entry.Response.Status != http.StatusCreated || entry.Response.Content.Text != string(body) {
+		t.Errorf("response = %+v, want the 201 with its body", entry.Response)
+	}
+	if strings.Contains(out.String(), "s3cret-token") || strings.Contains(out.String(), "session=abc") {
+		t.Errorf("recording contains credentials:\n%s", out.String())
+	}
+	for _, header := range append(entry.Request.Headers, entry.Response.Headers...) {
+		if (header.Name == "Authorization" || header.Name == "Set-Cookie") && header.Value != config.RedactedPlaceholder {
+			t.Errorf("header %s = %q, want it redacted", header.Name, header.Value)
+		}
+	}
+
+	out.Reset()
+	if err := recorder.Write(&out, RecordJSONLines); err != nil {
+		t.Fatalf("Write(jsonl) error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 1 {
+		t.Errorf("JSON lines recording has %d lines, want one per request", len(lines))
+	}
+
+	recorder.Reset()
+	if recorder.Len() != 0 {
+		t.Errorf("Len() after Reset() = %d, want 0", recorder.Len())
+	}
+}
+
+func TestRecorderTransportError(t *testing.T) {
+	recorder := NewRecorder()
+	fake := &fakeTransport{results: []any{errors.New("connection refused")}}
+	httpClient := &http.Client{Transport: recorder.Transport(fake)}
+
+	if _, err := httpClient.Get("http://maestro.example.com/api/maestro/v1/consumers"); err == nil {
+		t.Fatal("Get() succeeded, want the transport error")
+	}
+
+	var out bytes.Buffer
+	if err := recorder.Write(&out, RecordJSONLines); err != nil {
+		t.Fatal(err)
+	}
+	var entry harEntry
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Error != "connection refused" || entry.Response.Status != 0 {
+		t.Errorf("entry = %+v, want the transport error without a response", entry)
+	}
+}
+
+func TestValidateRecordFormat(t *testing.T) {
+	for _, format := range []string{RecordOff, RecordHAR, RecordJSONLines} {
+		if err := ValidateRecordFormat(format); err != nil {
+			t.Errorf("ValidateRecordFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := ValidateRecordFormat("pcap"); err == nil {
+		t.Error("ValidateRecordFormat(pcap) succeeded, want an error")
+	}
+}
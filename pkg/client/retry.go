@@ -0,0 +1,125 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+)
+
+// IdempotencyKeyHeader marks a POST the server deduplicates, which makes it safe to retry.
+// The HyperFleet API does not deduplicate by it, so the create calls of HyperFleetClient do not set it.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// retryTransport retries requests that failed with a transient error
+type retryTransport struct {
+	next   http.RoundTripper
+	policy config.RetryConfig
+	sleep  func(req *http.Request, d time.Duration) error
+}
+
+// NewRetryTransport wraps next so that requests failing with a transient error are sent again, up to
+// policy.Attempts times with exponential backoff:
+//   - GET, HEAD, OPTIONS, PUT and DELETE on connection errors and 5xx responses
+//   - other methods carrying an Idempotency-Key header the same way
+//   - other methods on errors proving the request never reached the server (dial and DNS errors)
+//
+// Requests whose body cannot be replayed are not retried.
+func NewRetryTransport(next http.RoundTripper, policy config.RetryConfig) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{next: next, policy: policy, sleep: sleepContext}
+}
+
+// RoundTrip sends the request, retrying it as long as the failure is transient and attempts remain
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		// RoundTrippers must not modify the request, retries send a copy with a fresh body
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.policy.Attempts || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		reason := "error"
+		var detail any = err
+		if err == nil {
+			reason, detail = "status", resp.StatusCode
+			// Drain the body so the connection is reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		logger.Warn("retrying HTTP request", "method", req.Method, "url", req.URL.Redacted(),
+			"attempt", attempt+1, "max_attempts", t.policy.Attempts, reason, detail, "backoff", backoff)
+
+		if err := t.sleep(req, backoff); err != nil {
+			return nil, err
+		}
+		backoff = min(2*backoff, t.policy.MaxBackoff)
+	}
+}
+
+// retryable reports whether a failed attempt may be sent again
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil && notSent(err) {
+		return true
+	}
+	if !idempotent(req) {
+		return false
+	}
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// idempotent reports whether sending the request twice has the same effect as sending it once
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// notSent reports whether err proves the request never reached the server
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// sleepContext waits for d or until the request is canceled
+func sleepContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+var testRetryPolicy = config.RetryConfig{Attempts: 4, Backoff: time.Second, MaxBackoff: 3 * time.Second}
+
+// fakeTransport replays errors and status codes, one per attempt, and records the request bodies
+type fakeTransport struct {
+	results []any // error or status code
+	bodies  []string
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	f.bodies = append(f.bodies, string(body))
+
+	result := f.results[len(f.bodies)-1]
+	if err, ok := result.(error); ok {
+		return nil, err
+	}
+	return &http.Response{StatusCode: result.(int), Body: http.NoBody, Request: req}, nil
+}
+
+// retryClient returns an HTTP client retrying over fake, recording the backoffs instead of sleeping
+func retryClient(fake *fakeTransport, backoffs *[]time.Duration) *http.Client {
+	transport := NewRetryTransport(fake, testRetryPolicy).(*retryTransport)
+	transport.sleep = func(_ *http.Request, d time.Duration) error {
+		*backoffs = append(*backoffs, d)
+		return nil
+	}
+	return &http.Client{Transport: transport}
+}
+
+var errConnectionReset = errors.New("connection reset by peer")
+
+func TestRetryTransport(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	tests := []struct {
+		name           string
+		method         string
+		idempotencyKey string
+		results        []any
+		wantStatus     int
+		wantErr        bool
+		wantAttempts   int
+	}{
+		{name: "GET on 5xx", method: http.MethodGet, results: []any{502, 503, 200}, wantStatus: 200, wantAttempts: 3},
+		{name: "GET on connection error", method: http.MethodGet, results: []any{errConnectionReset, 200}, wantStatus: 200, wantAttempts: 2},
+		{name: "GET on 4xx", method: http.MethodGet, results: []any{404}, wantStatus: 404, wantAttempts: 1},
+		{name: "attempts exhausted", method: http.MethodGet, results: []any{503, 503, 503, 503}, wantStatus: 503, wantAttempts: 4},
+		{name: "DELETE on 5xx", method: http.MethodDelete, results: []any{500, 204}, wantStatus: 204, wantAttempts: 2},
+		{name: "POST on 5xx", method: http.MethodPost, results: []any{503}, wantStatus: 503, wantAttempts: 1},
+		{name: "POST on connection error", method: http.MethodPost, results: []any{errConnectionReset}, wantErr: true, wantAttempts: 1},
+		{name: "POST on dial error", method: http.MethodPost, results: []any{dialErr, 201}, wantStatus: 201, wantAttempts: 2},
+		{
+			name: "POST with idempotency key on 5xx", method: http.MethodPost, idempotencyKey: "create-cluster-1",
+			results: []any{503, 201}, wantStatus: 201, wantAttempts: 2,
+		},
+		{name: "PATCH on 5xx", method: http.MethodPatch, results: []any{502}, wantStatus: 502, wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTransport{results: tt.results}
+			var backoffs []time.Duration
+
+			req, err := http.NewRequest(tt.method, "http://api.example.com/api/hyperfleet/v1/clusters", strings.NewReader(`{"name": "c"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.idempotencyKey != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.idempotencyKey)
+			}
+
+			resp, err := retryClient(fake, &backoffs).Do(req)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Do() error = %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+				}
+			}
+			if len(fake.bodies) != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", len(fake.bodies), tt.wantAttempts)
+			}
+			for i, body := range fake.bodies {
+				if body != `{"name": "c"}` {
+					t.Errorf("attempt %d sent body %q, want the original body", i+1, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	fake := &fakeTransport{results: []any{503, 503, 503, 200}}
+	var backoffs []time.Duration
+
+	resp, err := retryClient(fake, &backoffs).Get("http://api.example.com/api/hyperfleet/v1/clusters")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	if len(backoffs) != len(want) {
+		t.Fatalf("backoffs = %v, want %v", backoffs, want)
+	}
+	for i := range want {
+		if backoffs[i] != want[i] {
+			t.Errorf("backoffs = %v, want %v doubling up to the maximum", backoffs, want)
+			break
+		}
+	}
+}
+
+func TestRetryTransportCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	httpClient := &http.Client{Transport: NewRetryTransport(nil, config.RetryConfig{Attempts: 3, Backoff: time.Hour, MaxBackoff: time.Hour})}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if _, err := httpClient.Do(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want the backoff to end with the request context", err)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+// DefaultTimeout bounds each request of the HTTP clients in this package
+const DefaultTimeout = 30 * time.Second
+
+// NewTLSConfig builds the TLS settings shared by every client from the tls config section.
+// The CA bundle is trusted in addition to the system roots.
+func NewTLSConfig(c config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, // #nosec G402 -- explicit opt-in escape hatch, warned about at startup
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// NewTransport returns a transport with the default proxy, pooling and timeouts of http.DefaultTransport
+// and the configured TLS settings
+func NewTransport(c config.TLSConfig) (*http.Transport, error) {
+	tlsConfig, err := NewTLSConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// NewHTTPClient returns the HTTP client the HyperFleet, Maestro and token endpoint clients share, retrying
+// transient errors as configured. DefaultTimeout bounds a request including its retries.
+func NewHTTPClient(cfg *config.Config) (*http.Client, error) {
+	transport, err := NewTransport(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: NewRetryTransport(transport, cfg.Retry), Timeout: DefaultTimeout}, nil
+}
+
+// NewHyperFleetClientFromConfig creates a HyperFleet API client with the TLS and auth settings of cfg
+func NewHyperFleetClientFromConfig(cfg *config.Config) (*HyperFleetClient, error) {
+	httpClient, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewHyperFleetClient(cfg.API.URL, httpClient, WithAuth(cfg.Auth, httpClient))
+}
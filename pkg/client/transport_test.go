@@ -0,0 +1,156 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+// writePEM writes a PEM block to a file in dir and returns its path
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// clientCertificate issues a client certificate from a new CA and returns the CA pool and the cert and key files
+func clientCertificate(t *testing.T, dir string) (*x509.CertPool, string, string) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "e2e-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "hyperfleet-e2e"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return pool, writePEM(t, dir, "client.crt", "CERTIFICATE", certDER), writePEM(t, dir, "client.key", "EC PRIVATE KEY", keyDER)
+}
+
+// get sends a GET request to url with a client built from the TLS config
+func get(t *testing.T, tlsConfig config.TLSConfig, url string) error {
+	t.Helper()
+	httpClient, err := NewHTTPClient(&config.Config{TLS: tlsConfig})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	caFile := writePEM(t, t.TempDir(), "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	if err := get(t, config.TLSConfig{}, server.URL); err == nil {
+		t.Error("request to a server with a private CA succeeded without the CA bundle")
+	}
+	if err := get(t, config.TLSConfig{CAFile: caFile}, server.URL); err != nil {
+		t.Errorf("request with the CA bundle failed: %v", err)
+	}
+	if err := get(t, config.TLSConfig{InsecureSkipVerify: true}, server.URL); err != nil {
+		t.Errorf("request with insecureSkipVerify failed: %v", err)
+	}
+
+	// The httptest certificate is issued for example.com
+	if err := get(t, config.TLSConfig{CAFile: caFile, ServerName: "example.com"}, server.URL); err != nil {
+		t.Errorf("request with server name example.com failed: %v", err)
+	}
+	if err := get(t, config.TLSConfig{CAFile: caFile, ServerName: "api.other.test"}, server.URL); err == nil {
+		t.Error("request with a server name the certificate is not valid for succeeded")
+	}
+}
+
+func TestNewHTTPClientMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	clientCAs, certFile, keyFile := clientCertificate(t, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	caFile := writePEM(t, dir, "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	if err := get(t, config.TLSConfig{CAFile: caFile}, server.URL); err == nil {
+		t.Error("request without a client certificate succeeded")
+	}
+	if err := get(t, config.TLSConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}, server.URL); err != nil {
+		t.Errorf("request with the client certificate failed: %v", err)
+	}
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tlsConfig := range map[string]config.TLSConfig{
+		"missing CA bundle": {CAFile: filepath.Join(dir, "missing.crt")},
+		"CA bundle not PEM": {CAFile: notPEM},
+		"cert without key":  {CertFile: notPEM},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewTLSConfig(tlsConfig); err == nil {
+				t.Error("NewTLSConfig() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,6 +34,16 @@ func EnvVar(name string) string {
 	return EnvPrefix + "_" + name
 }
 
+// Profile config keys
+const (
+	// ProfileKey selects an entry of the profiles map
+	// Env: HYPERFLEET_PROFILE
+	ProfileKey = "profile"
+
+	// ProfilesKey is the config file map of named overlays of the base configuration
+	ProfilesKey = "profiles"
+)
+
 // API config keys
 var API = struct {
 	// URL is the HyperFleet API base URL
@@ -60,15 +71,85 @@ var Tests = struct {
 	// Env: SUITE_TIMEOUT
 	SuiteTimeout string
 
+	// FlakeAttempts is the number of times a failing spec is run before it counts as failed
+	// Env: FLAKE_ATTEMPTS
+	FlakeAttempts string
+
+	// Procs is the number of Ginkgo parallel processes the binary runs the specs in
+	// Env: PARALLEL_PROCS
+	Procs string
+
+	// ShardIndex is the zero-based shard of the filtered specs this run executes
+	// Env: SHARD_INDEX
+	ShardIndex string
+
+	// ShardCount is the number of shards the filtered specs are split into
+	// Env: SHARD_COUNT
+	ShardCount string
+
 	// JUnitReportPath is the path to write JUnit XML report
 	// Env: JUNIT_REPORT_PATH
 	JUnitReportPath string
+
+	// Preflight runs the environment checks of the doctor command before the suite starts
+	// Env: PREFLIGHT
+	Preflight string
+
+	// SkipLabelValidation disables the check that every spec has valid labels (local development only)
+	// Env: SKIP_LABEL_VALIDATION
+	SkipLabelValidation string
+
+	// QuarantineFile is the path of a quarantine.yaml listing known-flaky specs with expiry dates
+	// Env: QUARANTINE_FILE
+	QuarantineFile string
+
+	// QuarantineMode is how quarantined specs are handled: "run" (failures ignored) or "skip"
+	// Env: QUARANTINE_MODE
+	QuarantineMode string
+
+	// LeakCheck is how resources specs did not release are reported after the suite: "fail" or "warn"
+	// Env: LEAK_CHECK
+	LeakCheck string
+
+	// RunID identifies the run in the labels of created resources, generated when empty
+	// Env: E2E_RUN_ID
+	RunID string
+
+	// GracePeriod is how long cleanup may take after SIGINT/SIGTERM before the process exits (Go duration format)
+	// Env: GRACE_PERIOD
+	GracePeriod string
+
+	// DryRun renders payload templates, adapter values and Helm commands instead of running specs
+	// Env: DRY_RUN
+	DryRun string
+
+	// APIVersionCheck is what a server API differing from the client's OpenAPI spec does: warn, fail or off
+	// Env: API_VERSION_CHECK
+	APIVersionCheck string
+
+	// HTTPRecord is the format the HTTP interactions of failed specs are saved in: off, har or jsonl
+	// Env: HTTP_RECORD
+	HTTPRecord string
 }{
-	GinkgoLabelFilter: "tests.ginkgoLabelFilter",
-	GinkgoFocus:       "tests.focus",
-	GinkgoSkip:        "tests.ginkgoSkip",
-	SuiteTimeout:      "tests.suiteTimeout",
-	JUnitReportPath:   "tests.junitReportPath",
+	GinkgoLabelFilter:   "tests.ginkgoLabelFilter",
+	GinkgoFocus:         "tests.focus",
+	GinkgoSkip:          "tests.ginkgoSkip",
+	SuiteTimeout:        "tests.suiteTimeout",
+	FlakeAttempts:       "tests.flakeAttempts",
+	Procs:               "tests.procs",
+	ShardIndex:          "tests.shardIndex",
+	ShardCount:          "tests.shardCount",
+	JUnitReportPath:     "tests.junitReportPath",
+	Preflight:           "tests.preflight",
+	SkipLabelValidation: "tests.skipLabelValidation",
+	QuarantineFile:      "tests.quarantineFile",
+	QuarantineMode:      "tests.quarantineMode",
+	LeakCheck:           "tests.leakCheck",
+	RunID:               "tests.runId",
+	GracePeriod:         "tests.gracePeriod",
+	DryRun:              "tests.dryRun",
+	APIVersionCheck:     "tests.apiVersionCheck",
+	HTTPRecord:          "tests.httpRecord",
 }
 
 // Log config keys
@@ -98,7 +179,7 @@ type AdaptersConfig struct {
 
 // AdapterDeploymentConfig contains configuration for deploying adapters via Helm in tests.
 type AdapterDeploymentConfig struct {
-	ChartRepo     string `yaml:"chartRepo" mapstructure:"chartRepo"`
+	ChartRepo     string `yaml:"chartRepo" mapstructure:"chartRepo" redact:"url"`
 	ChartRef      string `yaml:"chartRef" mapstructure:"chartRef"`
 	ChartPath     string `yaml:"chartPath" mapstructure:"chartPath"`
 	ImageRegistry string `yaml:"imageRegistry" mapstructure:"imageRegistry"`
@@ -106,23 +187,72 @@ type AdapterDeploymentConfig struct {
 	ImageTag      string `yaml:"imageTag" mapstructure:"imageTag"`
 }
 
+// Auth modes of AuthConfig.Mode
+const (
+	AuthModeNone              = "none"
+	AuthModeToken             = "token"              // static bearer token
+	AuthModeTokenFile         = "token-file"         // bearer token read from a file, re-read when it expires
+	AuthModeClientCredentials = "client-credentials" // OAuth2 client credentials flow against TokenURL
+)
+
+// AuthConfig contains how requests to the HyperFleet API are authenticated
+// When Mode is empty it is inferred from the fields that are set
+type AuthConfig struct {
+	Mode         string   `yaml:"mode" mapstructure:"mode"`
+	Token        string   `yaml:"token" mapstructure:"token" redact:"secret"`
+	TokenFile    string   `yaml:"tokenFile" mapstructure:"tokenFile"`
+	TokenURL     string   `yaml:"tokenUrl" mapstructure:"tokenUrl" redact:"url"`
+	ClientID     string   `yaml:"clientId" mapstructure:"clientId"`
+	ClientSecret string   `yaml:"clientSecret" mapstructure:"clientSecret" redact:"secret"`
+	Scopes       []string `yaml:"scopes" mapstructure:"scopes"`
+}
+
+// TLSConfig contains the TLS settings of every HTTP client talking to the HyperFleet API, Maestro and token endpoints
+type TLSConfig struct {
+	CAFile             string `yaml:"caFile" mapstructure:"caFile"`         // PEM bundle trusted in addition to the system roots
+	CertFile           string `yaml:"certFile" mapstructure:"certFile"`     // client certificate for mTLS
+	KeyFile            string `yaml:"keyFile" mapstructure:"keyFile"`       // key of the client certificate
+	ServerName         string `yaml:"serverName" mapstructure:"serverName"` // overrides the name certificates are verified against
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify" mapstructure:"insecureSkipVerify"`
+}
+
+// RetryConfig contains how HTTP clients retry requests that failed with a transient error
+type RetryConfig struct {
+	Attempts   int           `yaml:"attempts" mapstructure:"attempts"`     // attempts per request, the first one included; 1 disables retries
+	Backoff    time.Duration `yaml:"backoff" mapstructure:"backoff"`       // wait before the first retry, doubled for each further retry
+	MaxBackoff time.Duration `yaml:"maxBackoff" mapstructure:"maxBackoff"` // upper bound of the wait between retries
+}
+
 // Config represents the e2e test configuration
 type Config struct {
+	Profile           string                  `yaml:"profile" mapstructure:"profile"`
 	Namespace         string                  `yaml:"namespace" mapstructure:"namespace"`
 	GCPProjectID      string                  `yaml:"gcpProjectId" mapstructure:"gcpProjectId"`
 	OutputDir         string                  `yaml:"outputDir" mapstructure:"outputDir"`
 	TestDataDir       string                  `yaml:"testDataDir" mapstructure:"testDataDir"`
 	API               APIConfig               `yaml:"api" mapstructure:"api"`
+	Auth              AuthConfig              `yaml:"auth" mapstructure:"auth"`
+	TLS               TLSConfig               `yaml:"tls" mapstructure:"tls"`
+	Retry             RetryConfig             `yaml:"retry" mapstructure:"retry"`
 	Timeouts          TimeoutsConfig          `yaml:"timeouts" mapstructure:"timeouts"`
 	Polling           PollingConfig           `yaml:"polling" mapstructure:"polling"`
 	Log               LogConfig               `yaml:"log" mapstructure:"log"`
 	Adapters          AdaptersConfig          `yaml:"adapters" mapstructure:"adapters"`
 	AdapterDeployment AdapterDeploymentConfig `yaml:"adapterDeployment" mapstructure:"adapterDeployment"`
+
+	// adapterDeploymentRequired is set by RequireAdapterDeployment
+	adapterDeploymentRequired bool
+
+	// apiURLOptional is set by AllowMissingAPIURL
+	apiURLOptional bool
+
+	// unknownKeys are config file keys that do not map to any field, recorded by Resolve
+	unknownKeys []string
 }
 
 // APIConfig contains API-related configuration
 type APIConfig struct {
-	URL string `yaml:"url" mapstructure:"url"`
+	URL string `yaml:"url" mapstructure:"url" redact:"url"`
 }
 
 // TimeoutsConfig contains timeout configurations
@@ -161,6 +291,29 @@ type LogConfig struct {
 
 // Load loads configuration from viper with improved validation
 func Load() (*Config, error) {
+	cfg, err := Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate with detailed errors
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Note: Display() is called after logger initialization in e2e.RunTests()
+	// to ensure structured logging is properly configured
+
+	return cfg, nil
+}
+
+// Resolve merges configuration from viper and applies defaults without validating it
+func Resolve() (*Config, error) {
+	// Overlay the selected profile on the config file layer, so env vars and flags still win
+	if err := applyProfile(viper.GetString(ProfileKey)); err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{}
 
 	// Use Unmarshal (not UnmarshalExact) to allow runtime test parameters (tests.*)
@@ -177,15 +330,31 @@ func Load() (*Config, error) {
 	// Apply defaults
 	cfg.applyDefaults()
 
-	// Validate with detailed errors
-	if err := cfg.Validate(); err != nil {
-		return nil, err
+	cfg.unknownKeys = unknownConfigFileKeys()
+
+	return cfg, nil
+}
+
+// applyProfile deep-merges profiles.<name> from the config file into the config file values
+func applyProfile(name string) error {
+	if name == "" {
+		return nil
 	}
 
-	// Note: Display() is called after logger initialization in e2e.RunTests()
-	// to ensure structured logging is properly configured
+	// Viper lowercases keys read from the config file
+	profiles := viper.GetStringMap(ProfilesKey)
+	overlay, ok := profiles[strings.ToLower(name)].(map[string]any)
+	if !ok {
+		available := make([]string, 0, len(profiles))
+		for profile := range profiles {
+			available = append(available, profile)
+		}
+		sort.Strings(available)
+		return fmt.Errorf("configuration error: profile %q not found in config file (available: %s)",
+			name, valueOrNotSet(strings.Join(available, ", ")))
+	}
 
-	return cfg, nil
+	return viper.MergeConfigMap(overlay)
 }
 
 // applyViperValues recursively applies values from viper to the config struct using reflection
@@ -270,22 +439,45 @@ func applyViperValues(v reflect.Value, prefix string) {
 	}
 }
 
+// explicitZeroKeys are the keys whose default is only applied when they are unset: an explicitly set zero
+// is kept, so Validate rejects it instead of the default silently replacing it
+var explicitZeroKeys = map[string]bool{
+	"timeouts.cluster.ready":      true,
+	"timeouts.nodepool.ready":     true,
+	"timeouts.adapter.processing": true,
+	"polling.interval":            true,
+	"retry.attempts":              true,
+	"retry.backoff":               true,
+	"retry.maxBackoff":            true,
+}
+
 // applyDefaults applies default values for unset fields
 func (c *Config) applyDefaults() {
 	// Apply timeout defaults
-	if c.Timeouts.Cluster.Ready == 0 {
+	if c.Timeouts.Cluster.Ready == 0 && !viper.IsSet("timeouts.cluster.ready") {
 		c.Timeouts.Cluster.Ready = DefaultClusterReadyTimeout
 	}
-	if c.Timeouts.NodePool.Ready == 0 {
+	if c.Timeouts.NodePool.Ready == 0 && !viper.IsSet("timeouts.nodepool.ready") {
 		c.Timeouts.NodePool.Ready = DefaultNodePoolReadyTimeout
 	}
-	if c.Timeouts.Adapter.Processing == 0 {
+	if c.Timeouts.Adapter.Processing == 0 && !viper.IsSet("timeouts.adapter.processing") {
 		c.Timeouts.Adapter.Processing = DefaultAdapterProcessingTimeout
 	}
-	if c.Polling.Interval == 0 {
+	if c.Polling.Interval == 0 && !viper.IsSet("polling.interval") {
 		c.Polling.Interval = DefaultPollInterval
 	}
 
+	// Apply HTTP retry defaults
+	if c.Retry.Attempts == 0 && !viper.IsSet("retry.attempts") {
+		c.Retry.Attempts = DefaultRetryAttempts
+	}
+	if c.Retry.Backoff == 0 && !viper.IsSet("retry.backoff") {
+		c.Retry.Backoff = DefaultRetryBackoff
+	}
+	if c.Retry.MaxBackoff == 0 && !viper.IsSet("retry.maxBackoff") {
+		c.Retry.MaxBackoff = DefaultRetryMaxBackoff
+	}
+
 	// Apply log defaults
 	if c.Log.Level == "" {
 		c.Log.Level = DefaultLogLevel
@@ -337,6 +529,26 @@ func (c *Config) applyDefaults() {
 		}
 	}
 
+	// Apply auth values from environment variables or config file
+	if c.Auth.Token == "" {
+		c.Auth.Token = os.Getenv("HYPERFLEET_API_TOKEN")
+	}
+	if c.Auth.TokenFile == "" {
+		c.Auth.TokenFile = os.Getenv("HYPERFLEET_API_TOKEN_FILE")
+	}
+	if c.Auth.TokenURL == "" {
+		c.Auth.TokenURL = os.Getenv("HYPERFLEET_TOKEN_URL")
+	}
+	if c.Auth.ClientID == "" {
+		c.Auth.ClientID = os.Getenv("HYPERFLEET_CLIENT_ID")
+	}
+	if c.Auth.ClientSecret == "" {
+		c.Auth.ClientSecret = os.Getenv("HYPERFLEET_CLIENT_SECRET")
+	}
+	if c.Auth.Mode == "" {
+		c.Auth.Mode = c.Auth.inferMode()
+	}
+
 	// Apply adapter deployment values from environment variables or config file
 
 	// ChartRepo: from ADAPTER_CHART_REPO env var or config file
@@ -372,25 +584,29 @@ func (c *Config) applyDefaults() {
 	}
 }
 
-// Validate validates configuration with detailed error messages
-func (c *Config) Validate() error {
-	// Validate API URL requirement
-	if c.API.URL == "" {
-		return fmt.Errorf(`configuration validation failed:
-  - Field 'Config.API.URL' is required
-    Please provide API URL (in order of priority):
-      • Flag: --api-url
-      • Environment variable: HYPERFLEET_API_URL
-      • Config file: api.url: <url>`)
+// inferMode picks the auth mode from the fields that are set, the most specific first
+func (a AuthConfig) inferMode() string {
+	switch {
+	case a.TokenURL != "" || a.ClientID != "":
+		return AuthModeClientCredentials
+	case a.TokenFile != "":
+		return AuthModeTokenFile
+	case a.Token != "":
+		return AuthModeToken
 	}
-
-	return nil
+	return AuthModeNone
 }
 
 // Display logs the merged configuration using structured logging
 func (c *Config) Display() {
 	slog.Info("Loaded configuration",
+		"profile", valueOrNotSet(c.Profile),
 		"api_url", redactURL(c.API.URL),
+		"auth_mode", c.Auth.Mode,
+		"tls_ca_file", valueOrNotSet(c.TLS.CAFile),
+		"tls_cert_file", valueOrNotSet(c.TLS.CertFile),
+		"tls_server_name", valueOrNotSet(c.TLS.ServerName),
+		"tls_insecure_skip_verify", c.TLS.InsecureSkipVerify,
 		"namespace", c.Namespace,
 		"gcp_project_id", c.GCPProjectID,
 		"output_dir", c.OutputDir,
@@ -399,6 +615,9 @@ func (c *Config) Display() {
 		"timeout_nodepool_ready", c.Timeouts.NodePool.Ready,
 		"timeout_adapter_processing", c.Timeouts.Adapter.Processing,
 		"polling_interval", c.Polling.Interval,
+		"retry_attempts", c.Retry.Attempts,
+		"retry_backoff", c.Retry.Backoff,
+		"retry_max_backoff", c.Retry.MaxBackoff,
 		"log_level", c.Log.Level,
 		"log_format", c.Log.Format,
 		"log_output", c.Log.Output,
@@ -411,6 +630,10 @@ func (c *Config) Display() {
 		"adapter_image_repo", valueOrNotSet(c.AdapterDeployment.ImageRepo),
 		"adapter_image_tag", valueOrNotSet(c.AdapterDeployment.ImageTag),
 	)
+
+	if c.TLS.InsecureSkipVerify {
+		slog.Warn("TLS certificate verification is disabled (tls.insecureSkipVerify), do not use outside local development")
+	}
 }
 
 // valueOrNotSet returns the value if non-empty, otherwise returns NotSetPlaceholder
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const profileTestConfig = `
+api:
+  url: http://api.example.com
+timeouts:
+  cluster:
+    ready: 5m
+  nodepool:
+    ready: 2m
+adapters:
+  cluster: [cl-namespace, cl-job]
+profiles:
+  kind:
+    timeouts:
+      cluster:
+        ready: 1m
+    adapters:
+      cluster: [cl-namespace]
+`
+
+// readTestConfig resets viper and loads content as the config file
+func readTestConfig(t *testing.T, content string) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	tests := []struct {
+		name             string
+		profile          string
+		wantClusterReady time.Duration
+		wantAdapters     []string
+		wantErr          string
+	}{
+		{
+			name:             "no profile",
+			wantClusterReady: 5 * time.Minute,
+			wantAdapters:     []string{"cl-namespace", "cl-job"},
+		},
+		{
+			name:             "profile overlays base values",
+			profile:          "kind",
+			wantClusterReady: time.Minute,
+			wantAdapters:     []string{"cl-namespace"},
+		},
+		{
+			name:    "unknown profile",
+			profile: "gke",
+			wantErr: `profile "gke" not found in config file (available: kind)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readTestConfig(t, profileTestConfig)
+			viper.Set(ProfileKey, tt.profile)
+
+			cfg, err := Resolve()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() failed: %v", err)
+			}
+
+			if cfg.Timeouts.Cluster.Ready != tt.wantClusterReady {
+				t.Errorf("Timeouts.Cluster.Ready = %s, want %s", cfg.Timeouts.Cluster.Ready, tt.wantClusterReady)
+			}
+			if cfg.Timeouts.NodePool.Ready != 2*time.Minute {
+				t.Errorf("Timeouts.NodePool.Ready = %s, want base value 2m", cfg.Timeouts.NodePool.Ready)
+			}
+			if !reflect.DeepEqual(cfg.Adapters.Cluster, tt.wantAdapters) {
+				t.Errorf("Adapters.Cluster = %v, want %v", cfg.Adapters.Cluster, tt.wantAdapters)
+			}
+		})
+	}
+}
+
+func TestResolveProfileBelowEnv(t *testing.T) {
+	readTestConfig(t, profileTestConfig)
+	BindEnv("timeouts.cluster.ready", EnvVar("TIMEOUTS_CLUSTER_READY"))
+	viper.Set(ProfileKey, "kind")
+	t.Setenv(EnvVar("TIMEOUTS_CLUSTER_READY"), "7m")
+
+	cfg, err := Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if cfg.Timeouts.Cluster.Ready != 7*time.Minute {
+		t.Errorf("Timeouts.Cluster.Ready = %s, want env value 7m", cfg.Timeouts.Cluster.Ready)
+	}
+}
@@ -53,6 +53,18 @@ const (
     DefaultLogOutput = LogOutputStdout
 )
 
+// Default HTTP retry values
+const (
+    // DefaultRetryAttempts is the default number of attempts of an HTTP request, the first one included
+    DefaultRetryAttempts = 3
+
+    // DefaultRetryBackoff is the default wait before the first retry, doubled for each further retry
+    DefaultRetryBackoff = time.Second
+
+    // DefaultRetryMaxBackoff is the default upper bound of the wait between retries
+    DefaultRetryMaxBackoff = 10 * time.Second
+)
+
 // Default required adapters for resource types
 var (
     // DefaultClusterAdapters is the default list of required adapters for cluster resources
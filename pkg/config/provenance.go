@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Value sources reported by Fields, from highest to lowest priority
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// TagRedact is the struct tag marking fields whose values must not be printed verbatim
+// "url" redacts credentials embedded in URLs, "secret" redacts the whole value
+const TagRedact = "redact"
+
+// Origin describes where an effective configuration value came from
+type Origin struct {
+	Source string `json:"source"`
+	Name   string `json:"name,omitempty"` // flag, environment variable or config file path; empty for defaults
+}
+
+// String formats the origin as "env (HYPERFLEET_API_URL)"
+func (o Origin) String() string {
+	if o.Name == "" {
+		return o.Source
+	}
+	return fmt.Sprintf("%s (%s)", o.Source, o.Name)
+}
+
+// Field is a single effective configuration value with its origin
+type Field struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Origin Origin `json:"origin"`
+}
+
+// bindings records the flags and env vars bound to each config key, which viper does not expose
+var bindings = struct {
+	sync.Mutex
+	flags map[string]*pflag.Flag
+	env   map[string][]string
+}{
+	flags: make(map[string]*pflag.Flag),
+	env:   make(map[string][]string),
+}
+
+// BindFlag binds a command-line flag to a config key and records it for provenance
+func BindFlag(key string, flag *pflag.Flag) {
+	if flag == nil {
+		return
+	}
+	_ = viper.BindPFlag(key, flag)
+
+	bindings.Lock()
+	defer bindings.Unlock()
+	bindings.flags[strings.ToLower(key)] = flag
+}
+
+// BindEnv binds environment variables to a config key and records them for provenance
+// Variables are checked in binding order, the first non-empty one wins
+func BindEnv(key string, envVars ...string) {
+	_ = viper.BindEnv(append([]string{key}, envVars...)...)
+
+	bindings.Lock()
+	defer bindings.Unlock()
+	lcaseKey := strings.ToLower(key)
+	bindings.env[lcaseKey] = append(bindings.env[lcaseKey], envVars...)
+}
+
+// fallbackEnvVars are the environment variables applyDefaults reads when a key is not set otherwise
+var fallbackEnvVars = map[string]string{
+	"namespace":                       "NAMESPACE",
+	"gcpProjectId":                    "GCP_PROJECT_ID",
+	"outputDir":                       "OUTPUT_DIR",
+	"testDataDir":                     "TESTDATA_DIR",
+	"auth.token":                      "HYPERFLEET_API_TOKEN",
+	"auth.tokenFile":                  "HYPERFLEET_API_TOKEN_FILE",
+	"auth.tokenUrl":                   "HYPERFLEET_TOKEN_URL",
+	"auth.clientId":                   "HYPERFLEET_CLIENT_ID",
+	"auth.clientSecret":               "HYPERFLEET_CLIENT_SECRET",
+	"adapterDeployment.chartRepo":     "ADAPTER_CHART_REPO",
+	"adapterDeployment.chartRef":      "ADAPTER_CHART_REF",
+	"adapterDeployment.chartPath":     "ADAPTER_CHART_PATH",
+	"adapterDeployment.imageRegistry": "IMAGE_REGISTRY",
+	"adapterDeployment.imageRepo":     "ADAPTER_IMAGE_REPO",
+	"adapterDeployment.imageTag":      "ADAPTER_IMAGE_TAG",
+}
+
+// Fields returns every leaf of the configuration with its display value and origin
+// Values of fields tagged with redact are redacted
+func (c *Config) Fields() []Field {
+	var fields []Field
+	collectFields(reflect.ValueOf(c).Elem(), "", &fields)
+	return fields
+}
+
+// collectFields walks the config struct by mapstructure tag, mirroring applyViperValues
+func collectFields(v reflect.Value, prefix string, fields *[]Field) {
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+
+		tag := fieldType.Tag.Get(TagMapstructure)
+		if tag == "" {
+			continue
+		}
+
+		configPath := tag
+		if prefix != "" {
+			configPath = prefix + "." + tag
+		}
+
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(time.Duration(0)) {
+			collectFields(field, configPath, fields)
+			continue
+		}
+
+		*fields = append(*fields, Field{
+			Key:    configPath,
+			Value:  displayValue(field, fieldType.Tag.Get(TagRedact)),
+			Origin: originOf(configPath),
+		})
+	}
+}
+
+// displayValue formats a leaf value the way Display does
+func displayValue(field reflect.Value, redact string) string {
+	var value string
+	switch {
+	case field.Kind() == reflect.Slice:
+		parts := make([]string, field.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(field.Index(i).Interface())
+		}
+		value = strings.Join(parts, ",")
+	default:
+		value = fmt.Sprint(field.Interface())
+	}
+
+	switch redact {
+	case "url":
+		return redactURL(value)
+	case "secret":
+		if value == "" {
+			return NotSetPlaceholder
+		}
+		return RedactedPlaceholder
+	}
+	return valueOrNotSet(value)
+}
+
+// originOf resolves where the value of a key came from, following viper's lookup order
+// (changed flag, env var, config file) and then the env var fallbacks of applyDefaults
+func originOf(key string) Origin {
+	bindings.Lock()
+	flag := bindings.flags[strings.ToLower(key)]
+	envVars := bindings.env[strings.ToLower(key)]
+	bindings.Unlock()
+
+	if flag != nil && flag.Changed {
+		return Origin{Source: SourceFlag, Name: "--" + flag.Name}
+	}
+
+	// AutomaticEnv is checked before explicitly bound variables
+	autoEnv := EnvVar(strings.ToUpper(strings.ReplaceAll(key, ".", "_")))
+	for _, envVar := range append([]string{autoEnv}, envVars...) {
+		if os.Getenv(envVar) != "" {
+			return Origin{Source: SourceEnv, Name: envVar}
+		}
+	}
+
+	if viper.InConfig(key) && (!isZero(viper.Get(key)) || explicitZeroKeys[key]) {
+		profile := strings.ToLower(viper.GetString(ProfileKey))
+		if profile != "" && viper.InConfig(ProfilesKey+"."+profile+"."+key) {
+			return Origin{Source: SourceFile, Name: viper.ConfigFileUsed() + ", profile " + profile}
+		}
+		return Origin{Source: SourceFile, Name: viper.ConfigFileUsed()}
+	}
+
+	if envVar, ok := fallbackEnvVars[key]; ok && os.Getenv(envVar) != "" {
+		return Origin{Source: SourceEnv, Name: envVar}
+	}
+
+	return Origin{Source: SourceDefault}
+}
+
+// isZero reports whether a raw viper value is empty, in which case applyDefaults still fills the field
+// unless the key is one of explicitZeroKeys
+func isZero(value any) bool {
+	if value == nil {
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
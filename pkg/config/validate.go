@@ -0,0 +1,339 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RequireAdapterDeployment makes Validate reject a configuration without the AdapterDeployment
+// chart settings, for runs whose selected specs deploy their own adapters
+func (c *Config) RequireAdapterDeployment() {
+	c.adapterDeploymentRequired = true
+}
+
+// AllowMissingAPIURL makes Validate accept a configuration without the API URL,
+// for dry runs that render the testdata without calling the API
+func (c *Config) AllowMissingAPIURL() {
+	c.apiURLOptional = true
+}
+
+// Validate validates configuration with detailed error messages
+// All problems are collected and reported together
+func (c *Config) Validate() error {
+	var problems []string
+
+	for _, key := range c.unknownKeys {
+		problem := fmt.Sprintf("  - Unknown key '%s' in config file", key)
+		if suggestion := suggestKey(key); suggestion != "" {
+			problem += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+		}
+		problems = append(problems, problem)
+	}
+
+	// Validate API URL requirement
+	if c.API.URL == "" {
+		if !c.apiURLOptional {
+			problems = append(problems, `  - Field 'Config.API.URL' is required
+    Please provide API URL (in order of priority):
+      • Flag: --api-url
+      • Environment variable: HYPERFLEET_API_URL
+      • Config file: api.url: <url>`)
+		}
+	} else if err := validateHTTPURL(c.API.URL); err != nil {
+		problems = append(problems, fmt.Sprintf("  - Field 'Config.API.URL' %v (got %s)", err, redactURL(c.API.URL)))
+	}
+
+	problems = append(problems, c.Auth.problems()...)
+	problems = append(problems, c.TLS.problems()...)
+
+	timeouts := []struct {
+		field string
+		value time.Duration
+	}{
+		{field: "Config.Timeouts.Cluster.Ready", value: c.Timeouts.Cluster.Ready},
+		{field: "Config.Timeouts.NodePool.Ready", value: c.Timeouts.NodePool.Ready},
+		{field: "Config.Timeouts.Adapter.Processing", value: c.Timeouts.Adapter.Processing},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			problems = append(problems, fmt.Sprintf("  - Field '%s' must be positive (got %s)", timeout.field, timeout.value))
+		}
+	}
+
+	if c.Polling.Interval <= 0 {
+		problems = append(problems, fmt.Sprintf("  - Field 'Config.Polling.Interval' must be positive (got %s)", c.Polling.Interval))
+	} else {
+		for _, timeout := range timeouts {
+			if timeout.value > 0 && c.Polling.Interval > timeout.value {
+				problems = append(problems, fmt.Sprintf(
+					"  - Field 'Config.Polling.Interval' (%s) must not exceed '%s' (%s)",
+					c.Polling.Interval, timeout.field, timeout.value))
+			}
+		}
+	}
+
+	if c.Retry.Attempts < 1 {
+		problems = append(problems, fmt.Sprintf("  - Field 'Config.Retry.Attempts' must be at least 1 (got %d)", c.Retry.Attempts))
+	}
+	if c.Retry.Backoff <= 0 {
+		problems = append(problems, fmt.Sprintf("  - Field 'Config.Retry.Backoff' must be positive (got %s)", c.Retry.Backoff))
+	} else if c.Retry.MaxBackoff < c.Retry.Backoff {
+		problems = append(problems, fmt.Sprintf("  - Field 'Config.Retry.MaxBackoff' (%s) must not be less than 'Config.Retry.Backoff' (%s)",
+			c.Retry.MaxBackoff, c.Retry.Backoff))
+	}
+
+	for _, group := range []struct {
+		field    string
+		adapters []string
+	}{
+		{field: "Config.Adapters.Cluster", adapters: c.Adapters.Cluster},
+		{field: "Config.Adapters.NodePool", adapters: c.Adapters.NodePool},
+	} {
+		for _, name := range group.adapters {
+			if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+				problems = append(problems, fmt.Sprintf("  - Field '%s' has invalid adapter name '%s': %s",
+					group.field, name, strings.Join(errs, "; ")))
+			}
+		}
+	}
+
+	if c.adapterDeploymentRequired {
+		if missing := c.MissingAdapterDeploymentFields(); len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf(`  - Fields %s are required by the selected specs that deploy adapters
+    Please set them in the config file or via environment variables, or exclude those specs with
+    --label-filter='!adapter-deployment'`, strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("configuration validation failed:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// problems reports an unknown auth mode and the fields the selected mode needs but are not set
+func (a AuthConfig) problems() []string {
+	var problems, missing []string
+	switch a.Mode {
+	case "", AuthModeNone:
+	case AuthModeToken:
+		if a.Token == "" {
+			missing = append(missing, "auth.token")
+		}
+	case AuthModeTokenFile:
+		if a.TokenFile == "" {
+			missing = append(missing, "auth.tokenFile")
+		}
+	case AuthModeClientCredentials:
+		if a.TokenURL == "" {
+			missing = append(missing, "auth.tokenUrl")
+		} else if err := validateHTTPURL(a.TokenURL); err != nil {
+			problems = append(problems, fmt.Sprintf("  - Field 'Config.Auth.TokenURL' %v (got %s)", err, redactURL(a.TokenURL)))
+		}
+		if a.ClientID == "" {
+			missing = append(missing, "auth.clientId")
+		}
+		if a.ClientSecret == "" {
+			missing = append(missing, "auth.clientSecret")
+		}
+	default:
+		return []string{fmt.Sprintf("  - Field 'Config.Auth.Mode' must be one of %s, %s, %s or %s (got %q)",
+			AuthModeNone, AuthModeToken, AuthModeTokenFile, AuthModeClientCredentials, a.Mode)}
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("  - Fields %s are required by auth mode %s", strings.Join(missing, ", "), a.Mode))
+	}
+	return problems
+}
+
+// problems reports TLS files that cannot be read and a client certificate without its key or the reverse
+func (t TLSConfig) problems() []string {
+	var problems []string
+	for _, file := range []struct {
+		field string
+		path  string
+	}{
+		{field: "Config.TLS.CAFile", path: t.CAFile},
+		{field: "Config.TLS.CertFile", path: t.CertFile},
+		{field: "Config.TLS.KeyFile", path: t.KeyFile},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			problems = append(problems, fmt.Sprintf("  - Field '%s' cannot be read: %v", file.field, err))
+		}
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		problems = append(problems, "  - Fields 'Config.TLS.CertFile' and 'Config.TLS.KeyFile' must be set together")
+	}
+	return problems
+}
+
+// MissingAdapterDeploymentFields returns the config keys that specs deploying adapters need but are not set
+func (c *Config) MissingAdapterDeploymentFields() []string {
+	var missing []string
+	if c.Namespace == "" {
+		missing = append(missing, "namespace")
+	}
+	if c.AdapterDeployment.ChartRepo == "" {
+		missing = append(missing, "adapterDeployment.chartRepo")
+	}
+	if c.AdapterDeployment.ChartRef == "" {
+		missing = append(missing, "adapterDeployment.chartRef")
+	}
+	if c.AdapterDeployment.ChartPath == "" {
+		missing = append(missing, "adapterDeployment.chartPath")
+	}
+	return missing
+}
+
+// validateHTTPURL rejects URLs that are not absolute http(s) URLs
+func validateHTTPURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("is not a valid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must use http or https")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("must include a host")
+	}
+	return nil
+}
+
+// knownKeys maps the lowercased config keys and sections accepted in the config file to their spelling
+func knownKeys() map[string]string {
+	known := map[string]string{ProfilesKey: ProfilesKey, "tests": "tests"}
+	collectKnownKeys(reflect.TypeOf(Config{}), "", known)
+
+	// Runtime test parameters are normally set via flags/env vars but are accepted in the file
+	tests := reflect.ValueOf(Tests)
+	for i := 0; i < tests.NumField(); i++ {
+		key := tests.Field(i).String()
+		known[strings.ToLower(key)] = key
+	}
+	return known
+}
+
+// collectKnownKeys walks the config struct by mapstructure tag, mirroring applyViperValues
+func collectKnownKeys(t reflect.Type, prefix string, known map[string]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(TagMapstructure)
+		if tag == "" {
+			continue
+		}
+
+		configPath := tag
+		if prefix != "" {
+			configPath = prefix + "." + tag
+		}
+		known[strings.ToLower(configPath)] = configPath
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)) {
+			collectKnownKeys(field.Type, configPath, known)
+		}
+	}
+}
+
+// unknownConfigFileKeys returns the config file keys, including those inside profiles,
+// that do not map to a config field, reported at the first unknown path segment
+func unknownConfigFileKeys() []string {
+	configFile := viper.ConfigFileUsed()
+	if configFile == "" {
+		return nil
+	}
+
+	// Read the file on its own: env bindings of whole sections shadow nested file keys in viper.AllKeys
+	fileViper := viper.New()
+	fileViper.SetConfigFile(configFile)
+	fileViper.SetConfigType("yaml")
+	if err := fileViper.ReadInConfig(); err != nil {
+		return nil
+	}
+
+	known := knownKeys()
+	unknown := make(map[string]bool)
+
+	for _, key := range fileViper.AllKeys() {
+
+		// Profile overlays accept the same keys as the base config
+		prefix, path := "", strings.Split(key, ".")
+		if path[0] == ProfilesKey {
+			if len(path) <= 2 {
+				continue
+			}
+			prefix, path = strings.Join(path[:2], ".")+".", path[2:]
+		}
+
+		for i := range path {
+			candidate := strings.Join(path[:i+1], ".")
+			if _, ok := known[candidate]; !ok {
+				unknown[prefix+candidate] = true
+				break
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(unknown))
+	for key := range unknown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// suggestKey returns the known key closest to a mistyped one, or "" when none is close
+func suggestKey(key string) string {
+	prefix, path := "", key
+	if parts := strings.SplitN(key, ".", 3); parts[0] == ProfilesKey && len(parts) == 3 {
+		prefix, path = parts[0]+"."+parts[1]+".", parts[2]
+	}
+
+	best, bestDistance := "", 3
+	for candidate, spelling := range knownKeys() {
+		// Only suggest keys at the same depth
+		if strings.Count(candidate, ".") != strings.Count(path, ".") {
+			continue
+		}
+		if d := editDistance(path, candidate); d < bestDistance || (d == bestDistance && spelling < best) {
+			best, bestDistance = spelling, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return prefix + best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// validConfig returns a configuration that passes Validate
+func validConfig() *Config {
+	return &Config{
+		Namespace: "hyperfleet-e2e",
+		API:       APIConfig{URL: "https://api.example.com"},
+		Timeouts: TimeoutsConfig{
+			Cluster:  ClusterTimeouts{Ready: 5 * time.Minute},
+			NodePool: NodePoolTimeouts{Ready: 2 * time.Minute},
+			Adapter:  AdapterTimeouts{Processing: 2 * time.Minute},
+		},
+		Polling: PollingConfig{Interval: 10 * time.Second},
+		Retry:   RetryConfig{Attempts: 3, Backoff: time.Second, MaxBackoff: 10 * time.Second},
+		Adapters: AdaptersConfig{
+			Cluster:  []string{"cl-namespace", "cl-job"},
+			NodePool: []string{"np-configmap"},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(c *Config) {},
+		},
+		{
+			name:    "missing API URL",
+			mutate:  func(c *Config) { c.API.URL = "" },
+			wantErr: []string{"Field 'Config.API.URL' is required"},
+		},
+		{
+			name: "missing API URL allowed for dry runs",
+			mutate: func(c *Config) {
+				c.API.URL = ""
+				c.AllowMissingAPIURL()
+			},
+		},
+		{
+			name: "invalid API URL rejected for dry runs",
+			mutate: func(c *Config) {
+				c.API.URL = "grpc://api.example.com"
+				c.AllowMissingAPIURL()
+			},
+			wantErr: []string{"Field 'Config.API.URL' must use http or https"},
+		},
+		{
+			name:    "non-http API URL",
+			mutate:  func(c *Config) { c.API.URL = "grpc://api.example.com" },
+			wantErr: []string{"Field 'Config.API.URL' must use http or https"},
+		},
+		{
+			name:    "API URL without host",
+			mutate:  func(c *Config) { c.API.URL = "https://" },
+			wantErr: []string{"Field 'Config.API.URL' must include a host"},
+		},
+		{
+			name: "non-positive timeouts",
+			mutate: func(c *Config) {
+				c.Timeouts.Cluster.Ready = 0
+				c.Timeouts.Adapter.Processing = -time.Minute
+			},
+			wantErr: []string{
+				"Field 'Config.Timeouts.Cluster.Ready' must be positive",
+				"Field 'Config.Timeouts.Adapter.Processing' must be positive",
+			},
+		},
+		{
+			name:    "polling interval larger than a timeout",
+			mutate:  func(c *Config) { c.Polling.Interval = 3 * time.Minute },
+			wantErr: []string{"must not exceed 'Config.Timeouts.NodePool.Ready'"},
+		},
+		{
+			name:    "non-positive polling interval",
+			mutate:  func(c *Config) { c.Polling.Interval = 0 },
+			wantErr: []string{"Field 'Config.Polling.Interval' must be positive"},
+		},
+		{
+			name:    "unknown auth mode",
+			mutate:  func(c *Config) { c.Auth.Mode = "basic" },
+			wantErr: []string{"Field 'Config.Auth.Mode' must be one of none, token, token-file or client-credentials"},
+		},
+		{
+			name:    "token mode without token",
+			mutate:  func(c *Config) { c.Auth.Mode = AuthModeToken },
+			wantErr: []string{"Fields auth.token are required by auth mode token"},
+		},
+		{
+			name: "incomplete client credentials",
+			mutate: func(c *Config) {
+				c.Auth = AuthConfig{Mode: AuthModeClientCredentials, TokenURL: "ftp://sso.example.com", ClientID: "e2e"}
+			},
+			wantErr: []string{
+				"Field 'Config.Auth.TokenURL' must use http or https",
+				"Fields auth.clientSecret are required by auth mode client-credentials",
+			},
+		},
+		{
+			name:   "client certificate without key",
+			mutate: func(c *Config) { c.TLS = TLSConfig{CertFile: "/nonexistent/client.crt"} },
+			wantErr: []string{
+				"Field 'Config.TLS.CertFile' cannot be read",
+				"Fields 'Config.TLS.CertFile' and 'Config.TLS.KeyFile' must be set together",
+			},
+		},
+		{
+			name: "invalid retry settings",
+			mutate: func(c *Config) {
+				c.Retry = RetryConfig{Attempts: -1, Backoff: 5 * time.Second, MaxBackoff: time.Second}
+			},
+			wantErr: []string{
+				"Field 'Config.Retry.Attempts' must be at least 1 (got -1)",
+				"Field 'Config.Retry.MaxBackoff' (1s) must not be less than 'Config.Retry.Backoff' (5s)",
+			},
+		},
+		{
+			name:    "adapter name not DNS-1123 compliant",
+			mutate:  func(c *Config) { c.Adapters.NodePool = []string{"np_ConfigMap"} },
+			wantErr: []string{"Field 'Config.Adapters.NodePool' has invalid adapter name 'np_ConfigMap'"},
+		},
+		{
+			name:   "adapter deployment not required",
+			mutate: func(c *Config) { c.AdapterDeployment = AdapterDeploymentConfig{} },
+		},
+		{
+			name: "adapter deployment required but incomplete",
+			mutate: func(c *Config) {
+				c.AdapterDeployment = AdapterDeploymentConfig{ChartRepo: "https://github.com/example/adapter.git"}
+				c.RequireAdapterDeployment()
+			},
+			wantErr: []string{"Fields adapterDeployment.chartRef, adapterDeployment.chartPath are required"},
+		},
+		{
+			name: "all problems reported together",
+			mutate: func(c *Config) {
+				c.API.URL = ""
+				c.Polling.Interval = -time.Second
+				c.unknownKeys = []string{"timeout"}
+			},
+			wantErr: []string{
+				"Unknown key 'timeout' in config file (did you mean 'timeouts'?)",
+				"Field 'Config.API.URL' is required",
+				"Field 'Config.Polling.Interval' must be positive",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() succeeded, want errors %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error does not contain %q:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestUnknownConfigFileKeys(t *testing.T) {
+	readTestConfig(t, `
+api:
+  url: https://api.example.com
+timeout:
+  cluster:
+    ready: 1m
+adapterDeployment:
+  chartRepo: https://github.com/example/adapter.git
+  chartRefs: main
+tests:
+  ginkgoLabelFilter: tier0
+profiles:
+  kind:
+    polling:
+      interval: 5s
+    poling:
+      interval: 5s
+`)
+
+	got := unknownConfigFileKeys()
+	want := []string{"adapterdeployment.chartrefs", "profiles.kind.poling", "timeout"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("unknownConfigFileKeys() = %v, want %v", got, want)
+	}
+
+	suggestions := map[string]string{
+		"timeout":                     "timeouts",
+		"adapterdeployment.chartrefs": "adapterDeployment.chartRef",
+		"profiles.kind.poling":        "profiles.kind.polling",
+		"completely-unrelated":        "",
+	}
+	for key, want := range suggestions {
+		if got := suggestKey(key); got != want {
+			t.Errorf("suggestKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestValidateExplicitZero(t *testing.T) {
+	readTestConfig(t, `
+api:
+  url: https://api.example.com
+timeouts:
+  cluster:
+    ready: 0s
+polling:
+  interval: 0
+`)
+
+	cfg, err := Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if cfg.Timeouts.Cluster.Ready != 0 || cfg.Polling.Interval != 0 {
+		t.Errorf("explicit zero values were replaced by defaults: cluster ready %s, polling interval %s",
+			cfg.Timeouts.Cluster.Ready, cfg.Polling.Interval)
+	}
+	if cfg.Timeouts.NodePool.Ready != DefaultNodePoolReadyTimeout {
+		t.Errorf("Timeouts.NodePool.Ready = %s, want the default for an unset key", cfg.Timeouts.NodePool.Ready)
+	}
+
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error for the zero values")
+	}
+	for _, want := range []string{
+		"Field 'Config.Timeouts.Cluster.Ready' must be positive (got 0s)",
+		"Field 'Config.Polling.Interval' must be positive (got 0s)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error does not contain %q:\n%v", want, err)
+		}
+	}
+
+	for _, field := range cfg.Fields() {
+		if field.Key == "timeouts.cluster.ready" && (field.Value != "0s" || field.Origin.Source != SourceFile) {
+			t.Errorf("timeouts.cluster.ready = %q from %s, want 0s from the config file", field.Value, field.Origin)
+		}
+	}
+}
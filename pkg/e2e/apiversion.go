@@ -0,0 +1,99 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+// envServerAPI passes the server API version found by the coordinating process to parallel processes,
+// process 1 records it in the reports
+const envServerAPI = "HYPERFLEET_E2E_SERVER_API"
+
+// apiVersionTimeout bounds the OpenAPI document request at suite start
+const apiVersionTimeout = 15 * time.Second
+
+// serverAPI is the OpenAPI document served by the API under test, nil when it was not checked
+var serverAPI *version.APIVersion
+
+// loadAPIVersionCheck reads and validates the configured API version check mode
+func loadAPIVersionCheck() (string, error) {
+	mode := viper.GetString(config.Tests.APIVersionCheck)
+	if mode == "" {
+		mode = version.APICheckWarn
+	}
+	if err := version.ValidateAPICheck(mode); err != nil {
+		return "", err
+	}
+	return mode, nil
+}
+
+// checkServerAPI retrieves the OpenAPI document served by the API and compares it with the spec the client
+// was generated from. A difference is logged, and returned as an error in fail mode. An API that does not
+// serve its document cannot be compared, which is only logged.
+func checkServerAPI(ctx context.Context, cfg *config.Config, mode string) (*version.APIVersion, error) {
+	if mode == version.APICheckOff || cfg == nil {
+		return nil, nil
+	}
+
+	cl, err := client.NewHyperFleetClientFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiVersionTimeout)
+	defer cancel()
+
+	document, err := cl.GetOpenAPISpec(ctx)
+	if err != nil {
+		log.Printf("Warning: cannot check the server API version: %v", err)
+		return nil, nil
+	}
+	served, err := version.ParseAPIVersion(document)
+	if err != nil {
+		log.Printf("Warning: cannot check the server API version: %v", err)
+		return nil, nil
+	}
+
+	build := version.Get()
+	mismatch := build.Mismatch(served)
+	switch {
+	case mismatch == "":
+		log.Printf("Server API version %s (client generated from OpenAPI spec %s)", served.SpecVersion, build.OpenAPISpecVersion)
+	case mode == version.APICheckFail:
+		return &served, fmt.Errorf("%s (use --api-version-check warn to run anyway)", mismatch)
+	default:
+		log.Printf("Warning: %s, specs may fail on API differences", mismatch)
+	}
+	return &served, nil
+}
+
+// serverAPIFromEnv returns the server API version passed by the coordinating process, if any
+func serverAPIFromEnv() *version.APIVersion {
+	value := os.Getenv(envServerAPI)
+	if value == "" {
+		return nil
+	}
+	var served version.APIVersion
+	if err := json.Unmarshal([]byte(value), &served); err != nil {
+		return nil
+	}
+	return &served
+}
+
+// serverAPIEnv returns the environment entry passing the server API version to parallel processes
+func serverAPIEnv() string {
+	if serverAPI == nil {
+		return envServerAPI + "="
+	}
+	data, _ := json.Marshal(serverAPI)
+	return envServerAPI + "=" + string(data)
+}
@@ -0,0 +1,77 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+func TestCheckServerAPI(t *testing.T) {
+	previous := version.Get()
+	t.Cleanup(func() { version.Set(previous) })
+	version.Set(version.Info{Version: "v1.0.0", OpenAPISpecHash: "client-spec", OpenAPISpecVersion: "1.0.4"})
+
+	served := "openapi: 3.0.0\ninfo:\n  version: 1.1.0\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+client.OpenAPIPath {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(served))
+	}))
+	defer server.Close()
+	cfg := &config.Config{API: config.APIConfig{URL: server.URL}}
+
+	got, err := checkServerAPI(context.Background(), cfg, version.APICheckWarn)
+	if err != nil {
+		t.Fatalf("checkServerAPI(warn) error = %v, want a warning only", err)
+	}
+	if got == nil || got.SpecVersion != "1.1.0" {
+		t.Errorf("checkServerAPI(warn) = %+v, want the served version 1.1.0", got)
+	}
+
+	if _, err := checkServerAPI(context.Background(), cfg, version.APICheckFail); err == nil ||
+		!strings.Contains(err.Error(), "server API version 1.1.0 differs") {
+		t.Errorf("checkServerAPI(fail) error = %v, want the version mismatch", err)
+	}
+
+	served = "openapi: 3.0.0\ninfo:\n  version: 1.0.4\n"
+	if _, err := checkServerAPI(context.Background(), cfg, version.APICheckFail); err != nil {
+		t.Errorf("checkServerAPI(fail) error = %v for a matching version", err)
+	}
+
+	if got, err := checkServerAPI(context.Background(), cfg, version.APICheckOff); got != nil || err != nil {
+		t.Errorf("checkServerAPI(off) = %+v, %v, want no check", got, err)
+	}
+}
+
+func TestCheckServerAPIUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	cfg := &config.Config{API: config.APIConfig{URL: server.URL}}
+
+	got, err := checkServerAPI(context.Background(), cfg, version.APICheckFail)
+	if got != nil || err != nil {
+		t.Errorf("checkServerAPI() = %+v, %v, want the check skipped when the document is not served", got, err)
+	}
+}
+
+func TestServerAPIEnv(t *testing.T) {
+	previous := serverAPI
+	t.Cleanup(func() { serverAPI = previous })
+
+	serverAPI = &version.APIVersion{SpecHash: "abc", SpecVersion: "1.0.4"}
+	name, value, _ := strings.Cut(serverAPIEnv(), "=")
+	t.Setenv(name, value)
+
+	got := serverAPIFromEnv()
+	if got == nil || *got != *serverAPI {
+		t.Errorf("serverAPIFromEnv() = %+v, want %+v", got, serverAPI)
+	}
+}
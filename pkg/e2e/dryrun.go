@@ -0,0 +1,127 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+)
+
+// dryRunChartDir stands in for the directory CloneHelmChart would clone the adapter chart into
+const dryRunChartDir = "<adapter chart clone>"
+
+// DryRunEnabled reports whether the test command renders the testdata instead of running specs
+func DryRunEnabled() bool {
+	return viper.GetBool(config.Tests.DryRun)
+}
+
+// DryRun renders what the specs would create, without any API, Kubernetes or Helm call: every payload
+// template under testdata/payloads, the values.yaml of every adapter under testdata/adapter-configs expanded
+// with envsubst, and the Helm command DeployAdapter would run for it. The rendered payloads, expanded values and
+// Helm commands are written to w and one problem is returned per template error, invalid payload or unresolved
+// ${VAR} placeholder.
+func DryRun(ctx context.Context, cfg *config.Config, w io.Writer) []string {
+	problems := dryRunPayloads(filepath.Join(cfg.TestDataDir, "payloads"), w)
+	return append(problems, dryRunAdapters(ctx, cfg, w)...)
+}
+
+// dryRunPayloads renders the JSON payload templates under dir and prints each rendered payload
+func dryRunPayloads(dir string, w io.Writer) []string {
+	var problems []string
+	_, _ = fmt.Fprintln(w, "Payload templates:")
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+		rendered, err := client.RenderPayloadFile(path)
+		if err == nil && !json.Valid(rendered) {
+			err = fmt.Errorf("rendered payload is not valid JSON")
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+		var indented bytes.Buffer
+		_ = json.Indent(&indented, rendered, "", "  ")
+		_, _ = fmt.Fprintf(w, "  %s:\n%s", path, indent(indented.Bytes(), "    "))
+		return nil
+	})
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("failed to list payload templates: %v", err))
+	}
+	return problems
+}
+
+// dryRunAdapters expands the values.yaml of every test adapter and prints it with the Helm command deploying it,
+// with ADAPTER_NAME set the way the specs set it before calling DeployAdapter
+func dryRunAdapters(ctx context.Context, cfg *config.Config, w io.Writer) []string {
+	if cfg.Namespace == "" {
+		return []string{"namespace is not set (NAMESPACE), adapters are deployed into it"}
+	}
+	dir := filepath.Join(cfg.TestDataDir, helper.AdapterConfigsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []string{fmt.Sprintf("failed to list adapter configs: %v", err)}
+	}
+
+	previous, wasSet := os.LookupEnv("ADAPTER_NAME")
+	defer func() {
+		if wasSet {
+			_ = os.Setenv("ADAPTER_NAME", previous)
+		} else {
+			_ = os.Unsetenv("ADAPTER_NAME")
+		}
+	}()
+
+	var problems []string
+	_, _ = fmt.Fprintln(w, "Adapter deployments:")
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		adapterName := entry.Name()
+		if err := os.Setenv("ADAPTER_NAME", adapterName); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", adapterName, err))
+			continue
+		}
+
+		result, err := helper.DryRunAdapter(ctx, cfg.TestDataDir, helper.AdapterDeploymentOptions{
+			ReleaseName: helper.GenerateAdapterReleaseName(adapterResourceType(adapterName), adapterName),
+			Namespace:   cfg.Namespace,
+			ChartPath:   filepath.Join(helper.TestWorkDir, dryRunChartDir, cfg.AdapterDeployment.ChartPath),
+			AdapterName: adapterName,
+		})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", adapterName, err))
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %s:\n    %s\n    values.yaml:\n%s", adapterName, strings.Join(result.HelmCommand, " "),
+			indent(result.Values, "      "))
+	}
+	return problems
+}
+
+// indent prefixes every line of text, which is terminated by a newline
+func indent(text []byte, prefix string) string {
+	lines := strings.Split(strings.TrimRight(string(text), "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix) + "\n"
+}
+
+// adapterResourceType returns the resource type of a test adapter from its name, np- adapters reconcile nodepools
+func adapterResourceType(adapterName string) string {
+	if strings.HasPrefix(adapterName, "np-") {
+		return helper.ResourceTypeNodepools
+	}
+	return helper.ResourceTypeClusters
+}
@@ -0,0 +1,64 @@
+package e2e
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDryRunPayloads(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"clusters/cluster-request.json":   `{"name": "hp-cluster-{{.Random}}"}`,
+		"clusters/unknown-variable.json":  `{"name": "{{.Missing}}"}`,
+		"nodepools/not-json.json":         `{"name": "np-{{.Random}}"`,
+		"clusters/adapter-values.yaml":    `topic: ${NOT_A_PAYLOAD}`,
+		"nodepools/nodepool-request.json": `{"name": "np-{{.Timestamp}}"}`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	problems := dryRunPayloads(dir, &out)
+
+	if len(problems) != 2 {
+		t.Fatalf("dryRunPayloads() = %q, want the unknown variable and the invalid JSON", problems)
+	}
+	if !strings.Contains(problems[0], "unknown-variable.json: failed to render template") {
+		t.Errorf("first problem = %q, want the template error", problems[0])
+	}
+	if !strings.Contains(problems[1], "not-json.json: rendered payload is not valid JSON") {
+		t.Errorf("second problem = %q, want the invalid JSON", problems[1])
+	}
+	for _, rendered := range []string{
+		"cluster-request.json:\n    {\n      \"name\": \"hp-cluster-",
+		"nodepool-request.json:\n    {\n      \"name\": \"np-",
+	} {
+		if !strings.Contains(out.String(), rendered) {
+			t.Errorf("output does not contain the rendered payload %q:\n%s", rendered, out.String())
+		}
+	}
+}
+
+func TestAdapterResourceType(t *testing.T) {
+	if got := adapterResourceType("np-configmap"); got != "nodepools" {
+		t.Errorf("adapterResourceType(np-configmap) = %q, want nodepools", got)
+	}
+	if got := adapterResourceType("cl-job"); got != "clusters" {
+		t.Errorf("adapterResourceType(cl-job) = %q, want clusters", got)
+	}
+}
+
+func TestIndent(t *testing.T) {
+	if got := indent([]byte("a: 1\nb:\n  c: 2\n"), "  "); got != "  a: 1\n  b:\n    c: 2\n" {
+		t.Errorf("indent() = %q", got)
+	}
+}
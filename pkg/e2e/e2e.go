@@ -3,6 +3,8 @@ package e2e
 import (
 	"context"
 	"log"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,9 +13,15 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/spf13/viper"
 
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
 )
 
+// suiteDescription is the top-level description passed to Ginkgo for the HyperFleet suite
+const suiteDescription = "HyperFleet E2E Suite"
+
 // RunTests is the main entry point for executing e2e tests from the CLI
 // It runs Ginkgo tests directly without using testing.Main (which calls os.Exit)
 func RunTests(ctx context.Context) int {
@@ -39,14 +47,174 @@ func RunTests(ctx context.Context) int {
 		suiteConfig.Timeout = 2 * time.Hour
 	}
 
+	// SIGINT/SIGTERM cancel ctx; Ginkgo then interrupts the running spec and runs the cleanup nodes
+	configureInterrupt(ctx, &suiteConfig)
+
+	// Parallel processes started by runParallel skip the checks the coordinating process already ran
+	worker, err := parallelWorkerFromEnv()
+	if err != nil {
+		log.Printf("Invalid parallel process settings: %v", err)
+		return 1
+	}
+	coordinator := worker == nil
+
+	// Reject specs that would silently drop out of tier-filtered runs, before any resources are created
+	if coordinator && !viper.GetBool(config.Tests.SkipLabelValidation) {
+		if problems := ValidateSpecLabels(); len(problems) > 0 {
+			log.Printf("Spec label validation failed (use --skip-label-validation for local development):\n  - %s",
+				strings.Join(problems, "\n  - "))
+			return 1
+		}
+	}
+
+	// Render what the specs would create instead of running them, e.g. to validate testdata changes
+	if coordinator && DryRunEnabled() {
+		return runDryRun(ctx)
+	}
+
+	// Quarantined specs are skipped or run without blocking, until their entries expire
+	q, mode, err := loadQuarantine(time.Now(), coordinator)
+	if err != nil {
+		log.Printf("Failed to load quarantine: %v", err)
+		return 1
+	}
+	activeQuarantine, quarantineMode = q, mode
+
+	if leakCheckMode, err = loadLeakCheck(); err != nil {
+		log.Printf("Failed to configure leak check: %v", err)
+		return 1
+	}
+
+	// Failed specs save the HTTP interactions of their helpers, recording costs nothing when off
+	if httpRecordFormat, err = loadHTTPRecord(); err != nil {
+		log.Printf("Failed to configure HTTP recording: %v", err)
+		return 1
+	}
+	if httpRecordFormat != client.RecordOff {
+		helper.SetHTTPRecorder(client.NewRecorder())
+	}
+
+	// Parallel processes receive the run ID of the coordinating process through the environment
+	if runID, err = loadRunID(time.Now()); err != nil {
+		log.Printf("Failed to configure run ID: %v", err)
+		return 1
+	}
+	helper.SetRunID(runID)
+	if coordinator {
+		build := version.Get()
+		log.Printf("hyperfleet-e2e %s (commit %s, built %s)", build.Version, build.Commit, build.Date)
+		log.Printf("Run ID: %s", runID)
+	}
+
+	// Specs are written against the OpenAPI spec the client was generated from, compare it with the server API
+	if coordinator {
+		mode, err := loadAPIVersionCheck()
+		if err != nil {
+			log.Printf("Failed to configure API version check: %v", err)
+			return 1
+		}
+		if serverAPI, err = checkServerAPI(ctx, GetSuiteConfig(), mode); err != nil {
+			log.Printf("Server API version check failed: %v", err)
+			return 1
+		}
+	} else {
+		serverAPI = serverAPIFromEnv()
+	}
+
+	// Validate the environment before spending suite time on specs that cannot pass
+	if coordinator && viper.GetBool(config.Tests.Preflight) {
+		report := RunPreflight(ctx, GetSuiteConfig())
+		_ = report.Print(os.Stdout)
+		if !report.Passed() {
+			log.Printf("Preflight checks failed, not running tests")
+			return 1
+		}
+	}
+
+	// The JUnit report is written below so non-blocking failures can be marked
+	junitReport := reporterConfig.JUnitReport
+	reporterConfig.JUnitReport = ""
+
+	// Split the filtered specs across CI jobs, each shard writes its own JUnit file
+	shard := Shard{Index: viper.GetInt(config.Tests.ShardIndex), Count: viper.GetInt(config.Tests.ShardCount)}
+	if err := shard.Validate(); err != nil {
+		log.Printf("Invalid sharding: %v", err)
+		return 1
+	}
+	if shard.Enabled() {
+		filters, selected, total := shardFocusFiles(ginkgo.PreviewSpecs(suiteDescription, suiteConfig, reporterConfig), shard)
+		suiteConfig.FocusFiles = filters
+		junitReport = shard.ReportPath(junitReport)
+		if coordinator {
+			log.Printf("Shard %d of %d: running %d of %d filtered specs", shard.Index, shard.Count, selected, total)
+		}
+	}
+
+	// Ginkgo only handles signals received once the suite runs
+	if err := context.Cause(ctx); ctx.Err() != nil {
+		log.Printf("Test execution cancelled before running specs: %v", err)
+		return 1
+	}
+
+	if procs := viper.GetInt(config.Tests.Procs); coordinator && procs > 1 {
+		return runParallel(procs, reporterConfig)
+	}
+	if worker != nil {
+		worker.apply(&suiteConfig)
+	}
+
 	// Run the test suite using Ginkgo's native GinkgoT
 	// This avoids testing.Main and its os.Exit call
-	passed := ginkgo.RunSpecs(ginkgo.GinkgoT(), "HyperFleet E2E Suite", suiteConfig, reporterConfig)
+	suiteReport = nil
+	passed := ginkgo.RunSpecs(ginkgo.GinkgoT(), suiteDescription, suiteConfig, reporterConfig)
+
+	// Only process 1 runs ReportAfterSuite with the aggregated report, its exit code is the run's
+	if worker != nil && worker.Process != 1 {
+		if !passed {
+			return 1
+		}
+		return 0
+	}
 
-	if !passed {
-		return 1
+	if suiteReport == nil {
+		log.Printf("Suite report was not captured, falling back to the overall suite result")
+		if !passed {
+			return 1
+		}
+		return 0
 	}
 
+	code := exitCode(*suiteReport, activeQuarantine)
+	if junitReport != "" {
+		if err := writeJUnitReport(*suiteReport, junitReport, activeQuarantine); err != nil {
+			log.Printf("%v", err)
+			code = 1
+		}
+	}
+	if cfg := GetSuiteConfig(); cfg != nil {
+		if err := writeRunReports(*suiteReport, cfg, shard, code == 0, activeQuarantine); err != nil {
+			log.Printf("%v", err)
+			code = 1
+		}
+	}
+
+	logResults(classifyResults(*suiteReport, activeQuarantine), activeQuarantine)
+
+	return code
+}
+
+// runDryRun prints the rendered testdata and returns the exit code of the dry run
+func runDryRun(ctx context.Context) int {
+	cfg := GetSuiteConfig()
+	if cfg == nil {
+		log.Printf("Dry run requires a loaded configuration")
+		return 1
+	}
+	if problems := DryRun(ctx, cfg, os.Stdout); len(problems) > 0 {
+		log.Printf("Dry run failed:\n  - %s", strings.Join(problems, "\n  - "))
+		return 1
+	}
+	log.Printf("Dry run passed, no specs were run")
 	return 0
 }
 
@@ -68,6 +236,10 @@ func configureGinkgoFromViper(suiteConfig *types.SuiteConfig, reporterConfig *ty
 		suiteConfig.SkipStrings = append(suiteConfig.SkipStrings, skipTests)
 	}
 
+	if flakeAttempts := viper.GetInt(config.Tests.FlakeAttempts); flakeAttempts > 0 {
+		suiteConfig.FlakeAttempts = flakeAttempts
+	}
+
 	if junitReport := viper.GetString(config.Tests.JUnitReportPath); junitReport != "" {
 		reporterConfig.JUnitReport = junitReport
 	}
@@ -0,0 +1,83 @@
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+// httpRecordFormat is the format the HTTP interactions of failed specs are saved in
+var httpRecordFormat = client.RecordOff
+
+// loadHTTPRecord reads and validates the configured HTTP recording format
+func loadHTTPRecord() (string, error) {
+	format := viper.GetString(config.Tests.HTTPRecord)
+	if format == "" {
+		format = client.RecordOff
+	}
+	if err := client.ValidateRecordFormat(format); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// httpRecordingPath returns where the HTTP interactions of a spec are saved: <output dir>/<spec>/http.<format>
+func httpRecordingPath(outputDir, specText, format string) string {
+	return filepath.Join(outputDir, client.SpecLabelValue(specText), "http."+format)
+}
+
+// saveHTTPRecording writes the interactions recorded during the current spec and attaches the file to it
+func saveHTTPRecording(recorder *client.Recorder, outputDir, format string) error {
+	if recorder.Len() == 0 {
+		return nil
+	}
+	path := httpRecordingPath(outputDir, ginkgo.CurrentSpecReport().FullText(), format)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create HTTP recording directory: %w", err)
+	}
+	file, err := os.Create(path) // #nosec G304 -- path is built from the configured output directory
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP recording: %w", err)
+	}
+	if err := recorder.Write(file, format); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write HTTP recording: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write HTTP recording: %w", err)
+	}
+
+	rel, err := filepath.Rel(outputDir, path)
+	if err != nil {
+		rel = path
+	}
+	ginkgo.AddReportEntry(report.ReportEntryArtifact, rel, ginkgo.ReportEntryVisibilityFailureOrVerbose)
+	return nil
+}
+
+// Record each spec from a clean buffer. The cleanup is registered before any spec node runs, so it runs last
+// and the recording includes the requests of AfterEach and DeferCleanup nodes.
+var _ = ginkgo.BeforeEach(func() {
+	recorder := helper.HTTPRecorder()
+	if recorder == nil {
+		return
+	}
+	recorder.Reset()
+	ginkgo.DeferCleanup(func() {
+		if !ginkgo.CurrentSpecReport().Failed() {
+			return
+		}
+		if err := saveHTTPRecording(recorder, GetSuiteConfig().OutputDir, httpRecordFormat); err != nil {
+			logger.Error("failed to save HTTP recording", "error", err)
+		}
+	})
+})
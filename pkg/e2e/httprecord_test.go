@@ -0,0 +1,38 @@
+package e2e
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+func TestHTTPRecordingPath(t *testing.T) {
+	spec := "[Suite: cluster] Creation should reach Ready"
+	got := httpRecordingPath("output", spec, client.RecordHAR)
+
+	want := filepath.Join("output", client.SpecLabelValue(spec), "http.har")
+	if got != want {
+		t.Errorf("httpRecordingPath() = %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(filepath.Base(filepath.Dir(got)), "suite-cluster-creation-should-reach-ready-") {
+		t.Errorf("httpRecordingPath() = %q, want a directory named after the spec", got)
+	}
+}
+
+func TestLoadHTTPRecord(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set(config.Tests.HTTPRecord, "")
+	if format, err := loadHTTPRecord(); err != nil || format != client.RecordOff {
+		t.Errorf("loadHTTPRecord() = %q, %v, want recording off by default", format, err)
+	}
+	viper.Set(config.Tests.HTTPRecord, "pcap")
+	if _, err := loadHTTPRecord(); err == nil {
+		t.Error("loadHTTPRecord() accepted an unknown format")
+	}
+}
@@ -0,0 +1,81 @@
+package e2e
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/interrupt"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+// DefaultGracePeriod is how long cleanup may take after SIGINT/SIGTERM before the run exits
+const DefaultGracePeriod = 5 * time.Minute
+
+// runContext is the context RunTests was called with, cancelled by the first SIGINT/SIGTERM
+var runContext = context.Background()
+
+// interrupted reports whether a signal cut the run short
+func interrupted() bool {
+	return runContext.Err() != nil
+}
+
+// configureInterrupt gives the cleanup after a signal the configured grace period, in the signal handler
+// and in Ginkgo, which runs every AfterEach, DeferCleanup and AfterSuite node of an interrupted suite for
+// up to its grace period
+func configureInterrupt(ctx context.Context, suiteConfig *types.SuiteConfig) {
+	runContext = ctx
+	grace := viper.GetDuration(config.Tests.GracePeriod)
+	if grace <= 0 {
+		grace = DefaultGracePeriod
+	}
+	interrupt.SetGracePeriod(grace)
+	suiteConfig.GracePeriod = grace
+	interrupt.OnForceExit(logCleanupStatus)
+}
+
+// cleanupStatus lists the resources created by the specs of this process and whether their cleanup
+// completed, leaks are the outcome of the final cleanup of the resources specs did not release
+func cleanupStatus(leaks []helper.Leak) []report.Cleanup {
+	// Leaks were unreleased entries when the final cleanup started, match them whatever their state now
+	key := func(entry helper.LedgerEntry) helper.LedgerEntry {
+		entry.Released = false
+		return entry
+	}
+	errs := make(map[helper.LedgerEntry]error, len(leaks))
+	for _, leak := range leaks {
+		errs[key(leak.LedgerEntry)] = leak.Err
+	}
+
+	var cleanups []report.Cleanup
+	for _, entry := range helper.SuiteLedger().Entries() {
+		cleanup := report.Cleanup{Kind: entry.Kind, Name: entry.Name, Spec: entry.Spec, Completed: entry.Released}
+		if err := errs[key(entry)]; err != nil {
+			cleanup.Error = err.Error()
+		}
+		cleanups = append(cleanups, cleanup)
+	}
+	return cleanups
+}
+
+// logCleanupStatus logs the cleanups that did not complete before the process is forced to exit
+func logCleanupStatus() {
+	pending := helper.SuiteLedger().Unreleased()
+	if len(pending) == 0 {
+		log.Printf("All resources created by specs were cleaned up")
+		return
+	}
+	log.Printf("%d resources created by specs were not cleaned up:", len(pending))
+	for _, entry := range pending {
+		if entry.Spec == "" {
+			log.Printf("  - %s", entry)
+			continue
+		}
+		log.Printf("  - %s, created by %q", entry, entry.Spec)
+	}
+}
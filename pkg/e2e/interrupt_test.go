@@ -0,0 +1,39 @@
+package e2e
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+func TestCleanupStatus(t *testing.T) {
+	ledger := helper.SuiteLedger()
+	ledger.Record(helper.LedgerEntry{Kind: helper.LedgerKindCluster, Name: "c1", Spec: "creates a cluster"})
+	ledger.Record(helper.LedgerEntry{Kind: helper.LedgerKindCluster, Name: "c2", Spec: "creates a cluster"})
+	ledger.Record(helper.LedgerEntry{Kind: helper.LedgerKindNodePool, Name: "np1", Owner: "c2", Spec: "creates a nodepool"})
+	t.Cleanup(func() { ledger.Release(helper.LedgerKindCluster, "c2") })
+
+	// c1 was released by its spec, the final cleanup of c2 failed
+	ledger.Release(helper.LedgerKindCluster, "c1")
+	leaks := []helper.Leak{
+		{LedgerEntry: ledger.Unreleased()[0], Err: errors.New("timed out")},
+		{LedgerEntry: ledger.Unreleased()[1]},
+	}
+
+	got := cleanupStatus(leaks)
+	want := []report.Cleanup{
+		{Kind: helper.LedgerKindCluster, Name: "c1", Spec: "creates a cluster", Completed: true},
+		{Kind: helper.LedgerKindCluster, Name: "c2", Spec: "creates a cluster", Error: "timed out"},
+		{Kind: helper.LedgerKindNodePool, Name: "np1", Spec: "creates a nodepool"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("cleanupStatus() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("cleanup %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package e2e
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+// writeJUnitReport writes the JUnit report generated by Ginkgo, records the attempts and artifacts of each spec
+// and marks failures of informing, flaky and quarantined specs, prefixing the failure type and message
+// with the reason so dashboards can separate them
+func writeJUnitReport(suiteReport types.Report, path string, q *quarantine.List) error {
+	if err := reporters.GenerateJUnitReportWithConfig(suiteReport, path, reporters.JunitReportConfig{}); err != nil {
+		return fmt.Errorf("failed to generate JUnit report: %w", err)
+	}
+
+	suites, err := report.ReadJUnit(path)
+	if err != nil {
+		return err
+	}
+
+	for i := range suites.TestSuites {
+		recordVersions(&suites.TestSuites[i])
+	}
+
+	// Ginkgo emits one test case per spec report, in report order
+	if len(suites.TestSuites) == 1 && len(suites.TestSuites[0].TestCases) == len(suiteReport.SpecReports) {
+		testCases := suites.TestSuites[0].TestCases
+		for i, spec := range suiteReport.SpecReports {
+			recordAttempts(&testCases[i], spec)
+			recordArtifacts(&testCases[i], spec)
+			markNonBlockingFailure(&testCases[i], spec, q)
+		}
+	}
+
+	return report.WriteJUnit(path, suites)
+}
+
+// recordVersions adds the framework build and server API versions to the properties of a test suite
+func recordVersions(suite *report.JUnitTestSuite) {
+	build := version.Get()
+	properties := []reporters.JUnitProperty{
+		{Name: report.PropertyFrameworkVersion, Value: build.Version},
+		{Name: report.PropertyFrameworkCommit, Value: build.Commit},
+		{Name: report.PropertyOpenAPISpecVersion, Value: build.OpenAPISpecVersion},
+		{Name: report.PropertyOpenAPISpecHash, Value: build.OpenAPISpecHash},
+	}
+	if serverAPI != nil {
+		properties = append(properties,
+			reporters.JUnitProperty{Name: report.PropertyServerAPIVersion, Value: serverAPI.SpecVersion},
+			reporters.JUnitProperty{Name: report.PropertyServerAPIHash, Value: serverAPI.SpecHash},
+		)
+	}
+	suite.Properties.Properties = append(suite.Properties.Properties, properties...)
+}
+
+// recordAttempts adds the attempts property to test cases of specs that ran
+func recordAttempts(testCase *report.JUnitTestCase, spec types.SpecReport) {
+	if spec.NumAttempts == 0 {
+		return
+	}
+	testCase.SetProperty(report.PropertyAttempts, strconv.Itoa(spec.NumAttempts))
+}
+
+// recordArtifacts adds an artifact property for each artifact the spec recorded with helper.RecordArtifact
+func recordArtifacts(testCase *report.JUnitTestCase, spec types.SpecReport) {
+	for _, entry := range spec.ReportEntries {
+		if entry.Name == report.ReportEntryArtifact {
+			testCase.SetProperty(report.PropertyArtifact, entry.StringRepresentation())
+		}
+	}
+}
+
+// markNonBlockingFailure rewrites the failure of a non-blocking spec,
+// e.g. type "failed" becomes "informing-failed" and the message gains an "[informing] " prefix
+func markNonBlockingFailure(testCase *report.JUnitTestCase, spec types.SpecReport, q *quarantine.List) {
+	if !spec.State.Is(types.SpecStateFailureStates) {
+		return
+	}
+	reason := nonBlockingReason(spec, q)
+	if reason == "" {
+		return
+	}
+	if failure := testCase.Failure; failure != nil {
+		failure.Type = reason + "-" + failure.Type
+		failure.Message = "[" + reason + "] " + failure.Message
+	}
+	if junitError := testCase.Error; junitError != nil {
+		junitError.Type = reason + "-" + junitError.Type
+		junitError.Message = "[" + reason + "] " + junitError.Message
+	}
+}
@@ -0,0 +1,138 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+func TestWriteJUnitReportMarksNonBlockingFailures(t *testing.T) {
+	report := types.Report{
+		SuiteDescription: suiteDescription,
+		SpecReports: types.SpecReports{
+			specReport(types.SpecStateFailed, labels.Tier0),
+			specReport(types.SpecStateFailed, labels.Tier1, labels.Informing),
+			specReport(types.SpecStatePanicked, labels.Tier1, labels.Flaky),
+			specReport(types.SpecStatePassed, labels.Tier2, labels.Informing),
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := writeJUnitReport(report, path, nil); err != nil {
+		t.Fatalf("writeJUnitReport() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	content := string(data)
+
+	for _, want := range []string{
+		`type="failed"`,
+		`message="[informing] boom" type="informing-failed"`,
+		`type="flaky-panicked"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("JUnit report does not contain %q:\n%s", want, content)
+		}
+	}
+	if strings.Count(content, "[informing]") != 1 {
+		t.Errorf("expected only the failed informing spec to be marked:\n%s", content)
+	}
+}
+
+func TestWriteJUnitReportRecordsAttempts(t *testing.T) {
+	flaked := specReport(types.SpecStatePassed, labels.Tier0)
+	flaked.NumAttempts = 3
+	passed := specReport(types.SpecStatePassed, labels.Tier1)
+	passed.NumAttempts = 1
+	skipped := specReport(types.SpecStateSkipped, labels.Tier2)
+
+	suiteReport := types.Report{
+		SuiteDescription: suiteDescription,
+		SpecReports:      types.SpecReports{flaked, passed, skipped},
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := writeJUnitReport(suiteReport, path, nil); err != nil {
+		t.Fatalf("writeJUnitReport() error: %v", err)
+	}
+
+	suites, err := report.ReadJUnit(path)
+	if err != nil {
+		t.Fatalf("ReadJUnit() error: %v", err)
+	}
+	testCases := suites.TestSuites[0].TestCases
+
+	for i, want := range []string{"3", "1", ""} {
+		if got := testCases[i].Property(report.PropertyAttempts); got != want {
+			t.Errorf("test case %d attempts = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestWriteJUnitReportRecordsArtifacts(t *testing.T) {
+	failed := specReport(types.SpecStateFailed, labels.Tier1)
+	failed.ReportEntries = types.ReportEntries{
+		{Name: report.ReportEntryArtifact, Value: types.WrapEntryValue("adapter-ab12")},
+		{Name: "other", Value: types.WrapEntryValue("ignored")},
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := writeJUnitReport(types.Report{SuiteDescription: suiteDescription, SpecReports: types.SpecReports{failed}}, path, nil); err != nil {
+		t.Fatalf("writeJUnitReport() error: %v", err)
+	}
+
+	results, err := report.LoadResults(path)
+	if err != nil {
+		t.Fatalf("LoadResults() error: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Artifacts) != 1 || results[0].Artifacts[0] != "adapter-ab12" {
+		t.Errorf("artifacts read back from JUnit = %+v, want [adapter-ab12]", results)
+	}
+}
+
+func TestReportsRecordNonBlockingFailures(t *testing.T) {
+	suite := types.Report{
+		SuiteDescription: suiteDescription,
+		SpecReports: types.SpecReports{
+			specReport(types.SpecStateFailed, labels.Tier0),
+			specReport(types.SpecStateFailed, labels.Tier1, labels.Informing),
+			specReport(types.SpecStatePassed, labels.Tier2, labels.Flaky),
+		},
+	}
+	dir := t.TempDir()
+	junitPath := filepath.Join(dir, "junit.xml")
+	if err := writeJUnitReport(suite, junitPath, nil); err != nil {
+		t.Fatalf("writeJUnitReport() error: %v", err)
+	}
+	jsonPath := filepath.Join(dir, report.JSONReportFile)
+	if err := reporters.GenerateJSONReport(withNonBlockingEntries(suite, nil), jsonPath); err != nil {
+		t.Fatalf("GenerateJSONReport() error: %v", err)
+	}
+	if len(suite.SpecReports[1].ReportEntries) != 0 {
+		t.Error("withNonBlockingEntries() modified the suite report")
+	}
+
+	for _, path := range []string{junitPath, jsonPath} {
+		results, err := report.LoadResults(path)
+		if err != nil {
+			t.Fatalf("LoadResults(%s) error: %v", path, err)
+		}
+		var got []string
+		for _, result := range results {
+			got = append(got, result.NonBlocking)
+		}
+		if strings.Join(got, ",") != ",informing," {
+			t.Errorf("non-blocking reasons from %s = %q, want only the informing failure marked", filepath.Base(path), got)
+		}
+	}
+}
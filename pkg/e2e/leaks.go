@@ -0,0 +1,51 @@
+package e2e
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+)
+
+// leakCheckMode is how the AfterSuite reports resources specs did not release
+var leakCheckMode = helper.LeakCheckFail
+
+// loadLeakCheck reads and validates the configured leak check mode
+func loadLeakCheck() (string, error) {
+	mode := viper.GetString(config.Tests.LeakCheck)
+	if mode == "" {
+		mode = helper.LeakCheckFail
+	}
+	if err := helper.ValidateLeakCheck(mode); err != nil {
+		return "", err
+	}
+	return mode, nil
+}
+
+// cleanupLeaks cleans up the resources recorded in the suite ledger that their specs did not release
+func cleanupLeaks(ctx context.Context) []helper.Leak {
+	if len(helper.SuiteLedger().Unreleased()) == 0 {
+		return nil
+	}
+	return helper.New().CleanupLeaks(ctx)
+}
+
+// leakFailure returns the list of leaked resources per spec to fail the suite with. In warn mode the
+// list is only logged and nothing is returned.
+func leakFailure(leaks []helper.Leak) string {
+	if len(leaks) == 0 {
+		return ""
+	}
+
+	message := helper.FormatLeaks(leaks)
+	if leakCheckMode == helper.LeakCheckWarn {
+		logger.Warn("specs leaked resources", "count", len(leaks))
+		ginkgo.GinkgoWriter.Print(message)
+		return ""
+	}
+	return message
+}
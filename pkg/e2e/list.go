@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+// SpecInfo describes a registered spec as seen by a dry-run walk of the suite
+type SpecInfo struct {
+	Suite     string   `json:"suite"`
+	Hierarchy []string `json:"hierarchy"`
+	Text      string   `json:"text"`
+	Labels    []string `json:"labels"`
+	Location  string   `json:"location"`
+	Serial    bool     `json:"serial,omitempty"`
+}
+
+// ListSpecs walks the registered suites in Ginkgo dry-run mode and returns the specs
+// selected by the configured label filter, focus and skip expressions.
+// No spec bodies or suite setup nodes are executed.
+func ListSpecs() []SpecInfo {
+	suiteConfig, reporterConfig := ginkgo.GinkgoConfiguration()
+	configureGinkgoFromViper(&suiteConfig, &reporterConfig)
+
+	return previewSpecs(suiteConfig, reporterConfig)
+}
+
+// ValidateSpecLabels checks the labels of every registered spec, ignoring the configured filters,
+// so a spec without a severity label cannot silently drop out of tier-filtered runs.
+// Disruptive and adapter-deploying specs must also be Serial so --procs never runs them alongside others.
+// Returns one problem per invalid spec.
+func ValidateSpecLabels() []string {
+	suiteConfig, reporterConfig := ginkgo.GinkgoConfiguration()
+
+	// GinkgoConfiguration returns the filters of the last PreviewSpecs call, clear them explicitly
+	suiteConfig.LabelFilter = ""
+	suiteConfig.FocusStrings, suiteConfig.SkipStrings = nil, nil
+	suiteConfig.FocusFiles, suiteConfig.SkipFiles = nil, nil
+
+	var problems []string
+	for _, spec := range previewSpecs(suiteConfig, reporterConfig) {
+		path := strings.Join(append(append([]string{}, spec.Hierarchy...), spec.Text), " > ")
+		if err := labels.ValidateLabels(spec.Labels); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", path, spec.Location, err))
+		}
+		if err := labels.ValidateSerial(spec.Labels, spec.Serial); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", path, spec.Location, err))
+		}
+	}
+	return problems
+}
+
+// previewSpecs walks the suite in dry-run mode with the given configuration
+func previewSpecs(suiteConfig types.SuiteConfig, reporterConfig types.ReporterConfig) []SpecInfo {
+	return specInfosFromReport(ginkgo.PreviewSpecs(suiteDescription, suiteConfig, reporterConfig))
+}
+
+// specInfosFromReport converts the It-node spec reports that were not filtered out into SpecInfo entries
+func specInfosFromReport(suiteReport types.Report) []SpecInfo {
+	specReports := suiteReport.SpecReports.WithLeafNodeType(types.NodeTypeIt)
+
+	// Ginkgo randomizes top-level containers; list in source order for stable output
+	sort.SliceStable(specReports, func(i, j int) bool {
+		if specReports[i].FileName() != specReports[j].FileName() {
+			return specReports[i].FileName() < specReports[j].FileName()
+		}
+		return specReports[i].LineNumber() < specReports[j].LineNumber()
+	})
+
+	specs := make([]SpecInfo, 0, len(specReports))
+	for _, spec := range specReports {
+		// Specs excluded by label filter, focus or skip are reported as skipped in dry-run mode
+		if spec.State.Is(types.SpecStateSkipped | types.SpecStatePending) {
+			continue
+		}
+
+		specs = append(specs, SpecInfo{
+			Suite:     report.SuiteName(spec.ContainerHierarchyTexts),
+			Hierarchy: spec.ContainerHierarchyTexts,
+			Text:      spec.LeafNodeText,
+			Labels:    spec.Labels(),
+			Location:  spec.LeafNodeLocation.String(),
+			Serial:    spec.IsSerial,
+		})
+	}
+	return specs
+}
@@ -0,0 +1,176 @@
+package e2e
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/interrupt"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/parallel"
+)
+
+// Environment variables that turn a re-executed binary into a Ginkgo parallel process
+const (
+	envParallelProcess = "HYPERFLEET_E2E_PARALLEL_PROCESS"
+	envParallelTotal   = "HYPERFLEET_E2E_PARALLEL_TOTAL"
+	envParallelHost    = "HYPERFLEET_E2E_PARALLEL_HOST"
+)
+
+// reportGracePeriod is how long to wait for the server to receive all reports once every process has exited
+const reportGracePeriod = time.Second
+
+// parallelWorker identifies the Ginkgo parallel process this binary runs as
+type parallelWorker struct {
+	Process int
+	Total   int
+	Host    string
+}
+
+// parallelWorkerFromEnv returns the worker settings passed by runParallel, or nil when not running as a worker
+func parallelWorkerFromEnv() (*parallelWorker, error) {
+	host := os.Getenv(envParallelHost)
+	if host == "" {
+		return nil, nil
+	}
+	process, err := strconv.Atoi(os.Getenv(envParallelProcess))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", envParallelProcess, err)
+	}
+	total, err := strconv.Atoi(os.Getenv(envParallelTotal))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", envParallelTotal, err)
+	}
+	if process < 1 || process > total {
+		return nil, fmt.Errorf("parallel process %d out of range 1-%d", process, total)
+	}
+	return &parallelWorker{Process: process, Total: total, Host: host}, nil
+}
+
+// apply configures Ginkgo to run as this parallel process
+func (w *parallelWorker) apply(suiteConfig *types.SuiteConfig) {
+	suiteConfig.ParallelProcess = w.Process
+	suiteConfig.ParallelTotal = w.Total
+	suiteConfig.ParallelHost = w.Host
+}
+
+// runParallel re-executes the current command as procs Ginkgo parallel processes coordinated by a local
+// server, the way `ginkgo -p` does. Every process loads the configuration itself, so the suite config and
+// helper state are initialized in each of them. Process 1 receives the aggregated report, writes the reports
+// and computes the exit code, which is returned.
+func runParallel(procs int, reporterConfig types.ReporterConfig) int {
+	executable, err := os.Executable()
+	if err != nil {
+		log.Printf("Failed to locate the hyperfleet-e2e binary: %v", err)
+		return 1
+	}
+
+	server, err := parallel.NewServer(procs, reporters.NewDefaultReporter(reporterConfig, formatter.ColorableStdOut))
+	if err != nil {
+		log.Printf("Failed to start parallel spec server: %v", err)
+		return 1
+	}
+	server.Start()
+	defer server.Close()
+
+	// The processes share our process group and handle interrupts themselves, keep waiting for their reports.
+	// Ctrl-C reaches the whole group, SIGTERM is usually sent to this process only and is forwarded.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	log.Printf("Running specs in %d parallel processes", procs)
+
+	type procResult struct {
+		proc     int
+		exitCode int
+	}
+	results := make(chan procResult, procs)
+	outputs := make([]*bytes.Buffer, procs)
+	var started []*exec.Cmd
+
+	for proc := 1; proc <= procs; proc++ {
+		cmd := exec.Command(executable, os.Args[1:]...) // #nosec G204 -- re-executes this binary with its own arguments
+		cmd.Env = append(os.Environ(),
+			envParallelProcess+"="+strconv.Itoa(proc),
+			envParallelTotal+"="+strconv.Itoa(procs),
+			envParallelHost+"="+server.Address(),
+			parallel.ProtocolEnvVar+"="+parallel.ProtocolHTTP,
+			envRunID+"="+runID,
+			serverAPIEnv(),
+		)
+
+		// Process 1 logs the run results after the suite; the output of the others only matters if they crash
+		outputs[proc-1] = &bytes.Buffer{}
+		if proc == 1 {
+			cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+		} else {
+			cmd.Stdout, cmd.Stderr = outputs[proc-1], outputs[proc-1]
+		}
+
+		if err := cmd.Start(); err != nil {
+			log.Printf("Failed to start parallel process %d: %v", proc, err)
+			for _, running := range started {
+				_ = running.Process.Kill()
+			}
+			return 1
+		}
+		started = append(started, cmd)
+		exited := &atomic.Bool{}
+		server.RegisterAlive(proc, func() bool { return !exited.Load() })
+
+		go func(proc int) {
+			_ = cmd.Wait()
+			exited.Store(true)
+			results <- procResult{proc: proc, exitCode: cmd.ProcessState.ExitCode()}
+		}(proc)
+	}
+
+	// Cut the cleanup of the processes short when this one is forced to exit
+	interrupt.OnForceExit(func() {
+		for _, cmd := range started {
+			_ = cmd.Process.Signal(syscall.SIGTERM)
+		}
+	})
+
+	exitCode := 1
+	for remaining := procs; remaining > 0; {
+		select {
+		case result := <-results:
+			remaining--
+			if result.proc == 1 {
+				exitCode = result.exitCode
+			}
+		case sig := <-signals:
+			log.Printf("Interrupt received, waiting for parallel processes to clean up")
+			if sig == syscall.SIGTERM {
+				for _, cmd := range started {
+					_ = cmd.Process.Signal(sig)
+				}
+			}
+		}
+	}
+
+	select {
+	case <-server.Done():
+	case <-time.After(reportGracePeriod):
+		// A process exited before reporting its results, its output is the only clue left
+		log.Printf("Timed out waiting for all parallel processes to report back")
+		for proc := 2; proc <= procs; proc++ {
+			log.Printf("Output from parallel process %d:\n%s", proc, outputs[proc-1].String())
+		}
+		return 1
+	}
+
+	return exitCode
+}
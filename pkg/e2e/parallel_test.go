@@ -0,0 +1,65 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestParallelWorkerFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *parallelWorker
+		wantErr bool
+	}{
+		{name: "not a worker", env: map[string]string{}},
+		{
+			name: "worker",
+			env:  map[string]string{envParallelHost: "http://127.0.0.1:1234", envParallelProcess: "2", envParallelTotal: "4"},
+			want: &parallelWorker{Process: 2, Total: 4, Host: "http://127.0.0.1:1234"},
+		},
+		{
+			name:    "process out of range",
+			env:     map[string]string{envParallelHost: "http://127.0.0.1:1234", envParallelProcess: "5", envParallelTotal: "4"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid total",
+			env:     map[string]string{envParallelHost: "http://127.0.0.1:1234", envParallelProcess: "1", envParallelTotal: "four"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{envParallelHost, envParallelProcess, envParallelTotal} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			got, err := parallelWorkerFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parallelWorkerFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == nil {
+				if got != nil && !tt.wantErr {
+					t.Errorf("parallelWorkerFromEnv() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("parallelWorkerFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelWorkerApply(t *testing.T) {
+	suiteConfig := types.NewDefaultSuiteConfig()
+	(&parallelWorker{Process: 3, Total: 4, Host: "http://127.0.0.1:1234"}).apply(&suiteConfig)
+
+	if suiteConfig.ParallelProcess != 3 || suiteConfig.ParallelTotal != 4 || suiteConfig.ParallelHost != "http://127.0.0.1:1234" {
+		t.Errorf("apply() = process %d of %d at %q, want process 3 of 4 at http://127.0.0.1:1234",
+			suiteConfig.ParallelProcess, suiteConfig.ParallelTotal, suiteConfig.ParallelHost)
+	}
+}
@@ -0,0 +1,30 @@
+package e2e
+
+import (
+	"context"
+	"slices"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/preflight"
+)
+
+// RunPreflight checks the environment needed by the specs selected by the configured filters.
+// The adapterDeployment settings are only required when a selected spec deploys its own adapters.
+func RunPreflight(ctx context.Context, cfg *config.Config) preflight.Report {
+	return preflight.Run(ctx, preflight.Options{
+		Config:                 cfg,
+		CheckAdapterDeployment: DeploysAdapters(),
+	})
+}
+
+// DeploysAdapters reports whether any spec selected by the configured filters
+// carries the adapter-deployment label
+func DeploysAdapters() bool {
+	for _, spec := range ListSpecs() {
+		if slices.Contains(spec.Labels, labels.AdapterDeployment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+)
+
+var (
+	// activeQuarantine holds the unexpired quarantine entries of the current run
+	activeQuarantine *quarantine.List
+
+	// quarantineMode is how quarantined specs are handled in the current run
+	quarantineMode = quarantine.ModeRun
+)
+
+// loadQuarantine reads the configured quarantine file, warns about expired entries when warn is set
+// and returns the entries still in effect at now. No file configured means no quarantine.
+func loadQuarantine(now time.Time, warn bool) (*quarantine.List, string, error) {
+	mode := viper.GetString(config.Tests.QuarantineMode)
+	if mode == "" {
+		mode = quarantine.ModeRun
+	}
+	if err := quarantine.ValidateMode(mode); err != nil {
+		return nil, "", err
+	}
+
+	path := viper.GetString(config.Tests.QuarantineFile)
+	if path == "" {
+		return nil, mode, nil
+	}
+
+	list, err := quarantine.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	active := list.Active(now)
+	if !warn {
+		return active, mode, nil
+	}
+
+	// Expired entries are blocking again, make that visible so they get fixed or renewed
+	if expired := list.Expired(now); len(expired) > 0 {
+		log.Printf("WARNING: %d quarantine entries in %s have expired, matching specs are blocking again:", len(expired), path)
+		for _, entry := range expired {
+			log.Printf("  - %q (%s, expired %s)", entry.Spec, entry.Ticket, entry.Expires)
+		}
+	}
+
+	if len(active.Entries) > 0 {
+		log.Printf("%d quarantine entries active (mode %s)", len(active.Entries), mode)
+	}
+	return active, mode, nil
+}
+
+// Skip quarantined specs before any of their setup runs
+var _ = ginkgo.BeforeEach(func() {
+	if quarantineMode != quarantine.ModeSkip {
+		return
+	}
+	if entry, ok := activeQuarantine.Match(ginkgo.CurrentSpecReport().FullText()); ok {
+		ginkgo.Skip(fmt.Sprintf("quarantined (%s, expires %s)", entry.Ticket, entry.Expires))
+	}
+})
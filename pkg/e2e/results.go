@@ -0,0 +1,61 @@
+package e2e
+
+import (
+	"log"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+)
+
+// runResults groups the spec reports of a run that need attention
+type runResults struct {
+	// Failed are the failures that fail the run, including suite setup and teardown nodes
+	Failed []types.SpecReport
+	// NonBlocking are the failures of informing, flaky and quarantined specs
+	NonBlocking []types.SpecReport
+	// PassedOnRetry are specs that failed at first and passed on a later flake attempt
+	PassedOnRetry []types.SpecReport
+}
+
+// classifyResults sorts the spec reports of a run into hard failures, non-blocking failures and flakes
+func classifyResults(report types.Report, q *quarantine.List) runResults {
+	var results runResults
+	for _, spec := range report.SpecReports {
+		switch {
+		case spec.State.Is(types.SpecStateFailureStates) && nonBlockingReason(spec, q) == "":
+			results.Failed = append(results.Failed, spec)
+		case spec.State.Is(types.SpecStateFailureStates):
+			results.NonBlocking = append(results.NonBlocking, spec)
+		case spec.State == types.SpecStatePassed && spec.NumAttempts > 1:
+			results.PassedOnRetry = append(results.PassedOnRetry, spec)
+		}
+	}
+	return results
+}
+
+// logResults prints the hard failures, non-blocking failures and specs that passed only on retry as separate lists
+func logResults(results runResults, q *quarantine.List) {
+	if len(results.Failed) > 0 {
+		log.Printf("%d spec(s) failed:", len(results.Failed))
+		for _, spec := range results.Failed {
+			log.Printf("  - %s (%s, %d attempt(s))", report.SpecName(spec), spec.LeafNodeLocation, spec.NumAttempts)
+		}
+	}
+
+	// Informing, flaky and quarantined specs run and are reported, but do not fail the run
+	if len(results.NonBlocking) > 0 {
+		log.Printf("%d non-blocking spec(s) failed:", len(results.NonBlocking))
+		for _, spec := range results.NonBlocking {
+			log.Printf("  - [%s] %s (%s)", nonBlockingReason(spec, q), report.SpecName(spec), spec.LeafNodeLocation)
+		}
+	}
+
+	if len(results.PassedOnRetry) > 0 {
+		log.Printf("%d spec(s) passed only on retry:", len(results.PassedOnRetry))
+		for _, spec := range results.PassedOnRetry {
+			log.Printf("  - %s (%s, %d attempts)", report.SpecName(spec), spec.LeafNodeLocation, spec.NumAttempts)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+)
+
+func TestClassifyResults(t *testing.T) {
+	hardFailure := specReport(types.SpecStateFailed, labels.Tier0)
+	hardFailure.NumAttempts = 3
+	informing := specReport(types.SpecStateFailed, labels.Tier1, labels.Informing)
+	passedOnRetry := specReport(types.SpecStatePassed, labels.Tier1)
+	passedOnRetry.NumAttempts = 2
+	passed := specReport(types.SpecStatePassed, labels.Tier2)
+	passed.NumAttempts = 1
+	setupFailure := types.SpecReport{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStateFailed}
+
+	results := classifyResults(types.Report{
+		SpecReports: types.SpecReports{hardFailure, informing, passedOnRetry, passed, setupFailure},
+	}, nil)
+
+	if len(results.Failed) != 2 || results.Failed[0].LeafNodeText != hardFailure.LeafNodeText ||
+		results.Failed[1].LeafNodeType != types.NodeTypeBeforeSuite {
+		t.Errorf("Failed = %v, want the tier0 spec and the BeforeSuite node", results.Failed)
+	}
+	if len(results.NonBlocking) != 1 || results.NonBlocking[0].LeafNodeText != informing.LeafNodeText {
+		t.Errorf("NonBlocking = %v, want the informing spec", results.NonBlocking)
+	}
+	if len(results.PassedOnRetry) != 1 || results.PassedOnRetry[0].LeafNodeText != passedOnRetry.LeafNodeText {
+		t.Errorf("PassedOnRetry = %v, want the spec that took 2 attempts", results.PassedOnRetry)
+	}
+}
@@ -0,0 +1,40 @@
+package e2e
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+// envRunID passes the run ID to parallel processes, so all of them label resources with the same one
+const envRunID = "E2E_RUN_ID"
+
+// runID identifies the current run in the labels of created resources and in the summary
+var runID string
+
+// loadRunID returns the configured run ID, or a new one when none is configured
+// The run ID is used as a label value, so it must be a valid Kubernetes label value.
+func loadRunID(now time.Time) (string, error) {
+	id := viper.GetString(config.Tests.RunID)
+	if id == "" {
+		return newRunID(now), nil
+	}
+	if errs := validation.IsValidLabelValue(id); len(errs) > 0 {
+		return "", fmt.Errorf("invalid run ID %q: %s", id, strings.Join(errs, "; "))
+	}
+	return id, nil
+}
+
+// newRunID returns a run ID made of the start time and a random suffix, e.g. 20261016-200719-3f9a2c
+func newRunID(now time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return now.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
@@ -0,0 +1,41 @@
+package e2e
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+func TestLoadRunID(t *testing.T) {
+	now := time.Date(2026, 10, 16, 20, 7, 19, 0, time.UTC)
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+		wantErr    bool
+	}{
+		{name: "generated", want: `^20261016-200719-[0-9a-f]{6}$`},
+		{name: "configured", configured: "ci-1234.5", want: `^ci-1234\.5$`},
+		{name: "not a label value", configured: "ci run/1234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set(config.Tests.RunID, tt.configured)
+
+			got, err := loadRunID(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadRunID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !regexp.MustCompile(tt.want).MatchString(got) {
+				t.Errorf("loadRunID() = %q, want match for %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package e2e
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// noSpecsFileFilter is a Ginkgo focus file filter no code location matches
+const noSpecsFileFilter = "^$"
+
+// Shard selects a deterministic subset of the filtered specs so a run can be split across CI jobs
+type Shard struct {
+	Index int // zero-based
+	Count int
+}
+
+// Enabled reports whether the run is split into more than one shard
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Validate checks the shard index is within the shard count
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must not be negative, got %d", s.Count)
+	}
+	if s.Count <= 1 {
+		if s.Index != 0 {
+			return fmt.Errorf("shard index %d requires a shard count greater than 1", s.Index)
+		}
+		return nil
+	}
+	if s.Index < 0 || s.Index >= s.Count {
+		return fmt.Errorf("shard index must be between 0 and %d, got %d", s.Count-1, s.Index)
+	}
+	return nil
+}
+
+// Contains reports whether the spec belongs to this shard
+// Specs are assigned by hashing their full text, so adding a spec does not move the others
+func (s Shard) Contains(spec types.SpecReport) bool {
+	if !s.Enabled() {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(shardKey(spec)))
+	return int(h.Sum32()%uint32(s.Count)) == s.Index // #nosec G115 -- Count is validated positive
+}
+
+// ReportPath returns the per-shard variant of a report path, e.g. results.xml becomes results-shard-1.xml
+func (s Shard) ReportPath(path string) string {
+	if !s.Enabled() || path == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-shard-" + strconv.Itoa(s.Index) + ext
+}
+
+// shardKey is the text a spec is hashed by
+// Specs in Ordered containers depend on each other, they are kept together by hashing their top-level container
+func shardKey(spec types.SpecReport) string {
+	if spec.IsInOrderedContainer && len(spec.ContainerHierarchyTexts) > 0 {
+		return spec.ContainerHierarchyTexts[0]
+	}
+	return spec.FullText()
+}
+
+// shardFocusFiles returns Ginkgo focus file filters ("file:line,line") selecting the leaf nodes of
+// the specs in this shard, among the specs a dry-run report selected after label, focus and skip filtering
+func shardFocusFiles(report types.Report, shard Shard) (filters []string, selected, total int) {
+	lines := make(map[string][]string)
+	for _, spec := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+		if spec.State.Is(types.SpecStateSkipped | types.SpecStatePending) {
+			continue
+		}
+		total++
+		if !shard.Contains(spec) {
+			continue
+		}
+		selected++
+		location := spec.LeafNodeLocation
+		lines[location.FileName] = append(lines[location.FileName], strconv.Itoa(location.LineNumber))
+	}
+
+	if selected == 0 {
+		return []string{noSpecsFileFilter}, 0, total
+	}
+
+	for fileName, fileLines := range lines {
+		filters = append(filters, "^"+regexp.QuoteMeta(fileName)+"$:"+strings.Join(fileLines, ","))
+	}
+	sort.Strings(filters)
+	return filters, selected, total
+}
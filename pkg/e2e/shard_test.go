@@ -0,0 +1,124 @@
+package e2e
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestShardValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		shard   Shard
+		wantErr bool
+	}{
+		{name: "disabled", shard: Shard{}},
+		{name: "single shard", shard: Shard{Index: 0, Count: 1}},
+		{name: "last shard", shard: Shard{Index: 3, Count: 4}},
+		{name: "index out of range", shard: Shard{Index: 4, Count: 4}, wantErr: true},
+		{name: "negative index", shard: Shard{Index: -1, Count: 4}, wantErr: true},
+		{name: "index without count", shard: Shard{Index: 1}, wantErr: true},
+		{name: "negative count", shard: Shard{Count: -2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.shard.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShardsPartitionSpecs(t *testing.T) {
+	var specs types.SpecReports
+	for i := 0; i < 50; i++ {
+		specs = append(specs, types.SpecReport{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeText:     fmt.Sprintf("spec %d", i),
+			LeafNodeLocation: types.CodeLocation{FileName: "/src/e2e/cluster/creation.go", LineNumber: 10 + i},
+			State:            types.SpecStatePassed,
+		})
+	}
+	// Filtered out by label filter, never assigned to a shard
+	specs = append(specs, types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: "filtered", State: types.SpecStateSkipped})
+
+	const count = 3
+	seen := make(map[string]int)
+	for index := 0; index < count; index++ {
+		shard := Shard{Index: index, Count: count}
+		_, selected, total := shardFocusFiles(types.Report{SpecReports: specs}, shard)
+		if total != 50 {
+			t.Errorf("shard %d: total = %d, want 50", index, total)
+		}
+		for _, spec := range specs[:50] {
+			if shard.Contains(spec) {
+				seen[spec.LeafNodeText]++
+				selected--
+			}
+		}
+		if selected != 0 {
+			t.Errorf("shard %d: focus filters and Contains disagree by %d specs", index, selected)
+		}
+	}
+
+	for _, spec := range specs[:50] {
+		if seen[spec.LeafNodeText] != 1 {
+			t.Errorf("spec %q assigned to %d shards, want 1", spec.LeafNodeText, seen[spec.LeafNodeText])
+		}
+	}
+}
+
+func TestShardFocusFiles(t *testing.T) {
+	specs := types.SpecReports{
+		{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeText:     "creates a cluster",
+			LeafNodeLocation: types.CodeLocation{FileName: "/src/e2e/cluster/creation.go", LineNumber: 42},
+			State:            types.SpecStatePassed,
+		},
+	}
+
+	filters, selected, _ := shardFocusFiles(types.Report{SpecReports: specs}, Shard{Index: 0, Count: 1})
+	if selected != 1 || len(filters) != 1 || filters[0] != `^/src/e2e/cluster/creation\.go$:42` {
+		t.Errorf("shardFocusFiles() = %v, %d, want one filter for creation.go:42", filters, selected)
+	}
+	if _, err := types.ParseFileFilters(filters); err != nil {
+		t.Errorf("filters %v are not valid Ginkgo file filters: %v", filters, err)
+	}
+
+	// A shard without specs must not fall back to running everything
+	filters, selected, _ = shardFocusFiles(types.Report{}, Shard{Index: 1, Count: 2})
+	if selected != 0 || len(filters) != 1 || filters[0] != noSpecsFileFilter {
+		t.Errorf("shardFocusFiles() of an empty shard = %v, want %q", filters, noSpecsFileFilter)
+	}
+}
+
+func TestShardKeepsOrderedContainersTogether(t *testing.T) {
+	shard := Shard{Index: 0, Count: 7}
+	first := shard.Contains(types.SpecReport{
+		ContainerHierarchyTexts: []string{"[Suite: adapter] failover", "ordered"},
+		LeafNodeText:            "step 1",
+		IsInOrderedContainer:    true,
+	})
+	for i := 2; i < 20; i++ {
+		spec := types.SpecReport{
+			ContainerHierarchyTexts: []string{"[Suite: adapter] failover", "ordered"},
+			LeafNodeText:            fmt.Sprintf("step %d", i),
+			IsInOrderedContainer:    true,
+		}
+		if shard.Contains(spec) != first {
+			t.Fatalf("ordered spec %q assigned to a different shard than step 1", spec.LeafNodeText)
+		}
+	}
+}
+
+func TestShardReportPath(t *testing.T) {
+	if got := (Shard{Index: 2, Count: 4}).ReportPath("out/results.xml"); got != "out/results-shard-2.xml" {
+		t.Errorf("ReportPath() = %q, want out/results-shard-2.xml", got)
+	}
+	if got := (Shard{}).ReportPath("out/results.xml"); got != "out/results.xml" {
+		t.Errorf("ReportPath() without sharding = %q, want unchanged path", got)
+	}
+}
@@ -0,0 +1,67 @@
+package e2e
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+)
+
+// quarantinedReason marks failures of specs matched by an active quarantine entry
+const quarantinedReason = "quarantined"
+
+// suiteReport is the final report of the last real (non dry-run) suite run, captured for RunTests
+var suiteReport *types.Report
+
+var _ = ginkgo.ReportAfterSuite("capture suite report", func(report ginkgo.Report) {
+	// PreviewSpecs walks the suite in dry-run mode for list and label validation, ignore those walks
+	if report.SuiteConfig.DryRun {
+		return
+	}
+	suiteReport = &report
+})
+
+// stabilityOf returns the stability label of a spec, specs without one are stable
+func stabilityOf(spec types.SpecReport) string {
+	for _, label := range spec.Labels() {
+		switch label {
+		case labels.Stable, labels.Informing, labels.Flaky:
+			return label
+		}
+	}
+	return labels.Stable
+}
+
+// nonBlockingReason returns why a failure of the spec does not fail the run
+// ("informing", "flaky" or "quarantined"), or "" when it blocks.
+// Suite setup and teardown nodes always block.
+func nonBlockingReason(spec types.SpecReport, q *quarantine.List) string {
+	if spec.LeafNodeType != types.NodeTypeIt {
+		return ""
+	}
+	if stability := stabilityOf(spec); stability != labels.Stable {
+		return stability
+	}
+	if _, ok := q.Match(spec.FullText()); ok {
+		return quarantinedReason
+	}
+	return ""
+}
+
+// exitCode computes the run result from stable, non-quarantined specs only
+// Interruptions, suite timeouts and other suite-level failures always fail the run
+func exitCode(report types.Report, q *quarantine.List) int {
+	if report.SuiteSucceeded {
+		return 0
+	}
+	if len(report.SpecialSuiteFailureReasons) > 0 {
+		return 1
+	}
+	for _, spec := range report.SpecReports {
+		if spec.State.Is(types.SpecStateFailureStates) && nonBlockingReason(spec, q) == "" {
+			return 1
+		}
+	}
+	return 0
+}
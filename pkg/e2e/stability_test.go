@@ -0,0 +1,122 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+)
+
+func specReport(state types.SpecState, specLabels ...string) types.SpecReport {
+	return types.SpecReport{
+		LeafNodeType:   types.NodeTypeIt,
+		LeafNodeText:   "spec " + strings.Join(specLabels, " "),
+		LeafNodeLabels: specLabels,
+		State:          state,
+		Failure:        types.Failure{Message: "boom"},
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		report types.Report
+		want   int
+	}{
+		{
+			name:   "suite succeeded",
+			report: types.Report{SuiteSucceeded: true},
+			want:   0,
+		},
+		{
+			name: "unlabeled spec failed",
+			report: types.Report{SpecReports: types.SpecReports{
+				specReport(types.SpecStateFailed, labels.Tier0),
+			}},
+			want: 1,
+		},
+		{
+			name: "stable spec timed out",
+			report: types.Report{SpecReports: types.SpecReports{
+				specReport(types.SpecStateTimedout, labels.Tier0, labels.Stable),
+			}},
+			want: 1,
+		},
+		{
+			name: "only informing and flaky specs failed",
+			report: types.Report{SpecReports: types.SpecReports{
+				specReport(types.SpecStatePassed, labels.Tier0),
+				specReport(types.SpecStateFailed, labels.Tier1, labels.Informing),
+				specReport(types.SpecStatePanicked, labels.Tier1, labels.Flaky),
+			}},
+			want: 0,
+		},
+		{
+			name: "suite setup failed",
+			report: types.Report{SpecReports: types.SpecReports{
+				{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStateFailed},
+			}},
+			want: 1,
+		},
+		{
+			name: "interrupted while an informing spec ran",
+			report: types.Report{
+				SpecialSuiteFailureReasons: []string{"Interrupted by User"},
+				SpecReports: types.SpecReports{
+					specReport(types.SpecStateInterrupted, labels.Tier1, labels.Informing),
+				},
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.report, nil); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuarantinedFailuresAreNonBlocking(t *testing.T) {
+	q, err := quarantine.Parse([]byte(`
+quarantine:
+  - spec: "spec tier1$"
+    ticket: HYPERFLEET-123
+    expires: "2099-01-01"
+`))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	report := types.Report{
+		SuiteDescription: suiteDescription,
+		SpecReports: types.SpecReports{
+			specReport(types.SpecStateFailed, labels.Tier1),
+		},
+	}
+	if got := exitCode(report, nil); got != 1 {
+		t.Errorf("exitCode() without quarantine = %d, want 1", got)
+	}
+	if got := exitCode(report, q); got != 0 {
+		t.Errorf("exitCode() with quarantine = %d, want 0", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := writeJUnitReport(report, path, q); err != nil {
+		t.Fatalf("writeJUnitReport() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if want := `message="[quarantined] boom" type="quarantined-failed"`; !strings.Contains(string(data), want) {
+		t.Errorf("JUnit report does not contain %q:\n%s", want, data)
+	}
+}
@@ -1,6 +1,7 @@
 package e2e
 
 import (
+	"context"
 	"log"
 
 	"github.com/onsi/ginkgo/v2"
@@ -8,6 +9,8 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
 )
 
 var (
@@ -32,7 +35,7 @@ var _ = ginkgo.BeforeSuite(func() {
 		log.Fatalf("Suite config not initialized")
 	}
 
-	if err := logger.Init(&cfg.Log, "dev"); err != nil {
+	if err := logger.Init(&cfg.Log, version.Get().Version); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
 
@@ -41,7 +44,22 @@ var _ = ginkgo.BeforeSuite(func() {
 	logger.Info("starting hyperfleet-e2e test suite - each test creates temporary resources")
 })
 
-var _ = ginkgo.AfterSuite(func() {
+var _ = ginkgo.AfterSuite(func(ctx context.Context) {
+	// Specs clean up after themselves; anything still unreleased in the ledger leaked
+	leaks := cleanupLeaks(ctx)
+
+	// Specs of an interrupted run leave their resources behind by design, list what was cleaned up instead
+	failure := ""
+	if interrupted() {
+		ginkgo.AddReportEntry(report.ReportEntryCleanups, cleanupStatus(leaks), ginkgo.ReportEntryVisibilityNever)
+	} else {
+		failure = leakFailure(leaks)
+	}
+
 	helper.ClearSuiteConfig()
 	logger.Info("test suite completed")
+
+	if failure != "" {
+		ginkgo.Fail(failure)
+	}
 })
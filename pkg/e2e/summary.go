@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/quarantine"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+// writeRunReports writes the Ginkgo JSON report, summary.json and summary.md to the output directory
+// Sharded runs write per-shard files, e.g. summary-shard-1.json
+func writeRunReports(suiteReport types.Report, cfg *config.Config, shard Shard, passed bool, q *quarantine.List) error {
+	outputDir := cfg.OutputDir
+
+	jsonReport := withNonBlockingEntries(suiteReport, q)
+	if err := reporters.GenerateJSONReport(jsonReport, filepath.Join(outputDir, shard.ReportPath(report.JSONReportFile))); err != nil {
+		return fmt.Errorf("failed to generate JSON report: %w", err)
+	}
+
+	summary := report.NewSummary(suiteReport, passed, func(spec types.SpecReport) string {
+		return nonBlockingReason(spec, q)
+	})
+	summary.RunID = runID
+	summary.Config = configValues(cfg)
+	summary.Versions = &report.Versions{Framework: version.Get(), ServerAPI: serverAPI}
+
+	if err := report.WriteSummary(filepath.Join(outputDir, shard.ReportPath(report.SummaryFile)), summary); err != nil {
+		return err
+	}
+	return report.WriteMarkdown(filepath.Join(outputDir, shard.ReportPath(report.SummaryMarkdownFile)), summary)
+}
+
+// withNonBlockingEntries returns a copy of the report whose failed informing, flaky and quarantined specs carry
+// the reason in a report.ReportEntryNonBlocking entry, the JSON counterpart of the JUnit failure type prefix
+func withNonBlockingEntries(suiteReport types.Report, q *quarantine.List) types.Report {
+	specs := make(types.SpecReports, len(suiteReport.SpecReports))
+	copy(specs, suiteReport.SpecReports)
+	for i, spec := range specs {
+		if !spec.State.Is(types.SpecStateFailureStates) {
+			continue
+		}
+		reason := nonBlockingReason(spec, q)
+		if reason == "" {
+			continue
+		}
+		entries := append(types.ReportEntries{}, spec.ReportEntries...)
+		specs[i].ReportEntries = append(entries, types.ReportEntry{
+			Name:       report.ReportEntryNonBlocking,
+			Value:      types.WrapEntryValue(reason),
+			Visibility: types.ReportEntryVisibilityNever,
+			Time:       spec.EndTime,
+		})
+	}
+	suiteReport.SpecReports = specs
+	return suiteReport
+}
+
+// configValues returns the effective configuration by key, redacted the way config show displays it
+func configValues(cfg *config.Config) map[string]string {
+	values := make(map[string]string)
+	for _, field := range cfg.Fields() {
+		values[field.Key] = field.Value
+	}
+	return values
+}
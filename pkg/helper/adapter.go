@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -48,8 +51,18 @@ type AdapterDeploymentOptions struct {
 // The name is truncated to 48 characters to leave room for Helm's deployment/pod suffixes (Kubernetes has a 63-char limit)
 // If truncation is needed, the random suffix is always preserved to maintain uniqueness
 func GenerateAdapterReleaseName(resourceType, adapterName string) string {
-	randomSuffix := generateRandomString(5)
+	randomSuffix := generateRandomString(adapterReleaseSuffixLength)
 
+	releaseName := fmt.Sprintf("%s-%s", adapterReleaseBaseName(resourceType, adapterName), randomSuffix)
+	return releaseName
+}
+
+// adapterReleaseSuffixLength is the length of the random suffix appended to adapter release names
+const adapterReleaseSuffixLength = 5
+
+// adapterReleaseBaseName returns the release name of an adapter without its random suffix,
+// truncated so that the full release name fits the Helm/Kubernetes length budget
+func adapterReleaseBaseName(resourceType, adapterName string) string {
 	// Kubernetes resource names have a 63-character limit
 	// Reserve ~15 characters for Helm's deployment/pod suffixes
 	maxReleaseNameLength := 48
@@ -58,38 +71,23 @@ func GenerateAdapterReleaseName(resourceType, adapterName string) string {
 	baseWithoutSuffix := fmt.Sprintf("adapter-%s-%s", resourceType, adapterName)
 
 	// Calculate how much space we have for the base (reserve space for "-" + suffix)
-	maxBaseLength := maxReleaseNameLength - len(randomSuffix) - 1
+	maxBaseLength := maxReleaseNameLength - adapterReleaseSuffixLength - 1
 
 	// Truncate the base if necessary, but always keep the suffix
 	if len(baseWithoutSuffix) > maxBaseLength {
 		baseWithoutSuffix = baseWithoutSuffix[:maxBaseLength]
 	}
 
-	releaseName := fmt.Sprintf("%s-%s", baseWithoutSuffix, randomSuffix)
-	return releaseName
+	return baseWithoutSuffix
 }
 
 // DeployAdapter deploys an adapter using Helm upgrade --install
 // This is a common function that can be reused across test cases
 // The release name must be provided via opts.ReleaseName - use GenerateAdapterReleaseName() to create a unique name
 func (h *Helper) DeployAdapter(ctx context.Context, opts AdapterDeploymentOptions) error {
-	// Validate required fields
-	if opts.Namespace == "" {
-		return fmt.Errorf("AdapterDeploymentOptions.Namespace is required")
-	}
-	if opts.ChartPath == "" {
-		return fmt.Errorf("AdapterDeploymentOptions.ChartPath is required")
-	}
-	if opts.AdapterName == "" {
-		return fmt.Errorf("AdapterDeploymentOptions.AdapterName is required")
-	}
-	if opts.ReleaseName == "" {
-		return fmt.Errorf("AdapterDeploymentOptions.ReleaseName is required - use GenerateAdapterReleaseName() to create a unique name")
-	}
-
-	// Set default timeout if not specified
-	if opts.Timeout == 0 {
-		opts.Timeout = 5 * time.Minute
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return err
 	}
 
 	releaseName := opts.ReleaseName
@@ -136,26 +134,12 @@ func (h *Helper) DeployAdapter(ctx context.Context, opts AdapterDeploymentOption
 
 	logger.Info("successfully expanded environment variables in values.yaml")
 
-	// Build Helm command with single values file
-	helmArgs := []string{
-		"upgrade", "--install",
-		releaseName,
-		opts.ChartPath,
-		"--namespace", opts.Namespace,
-		"--create-namespace",
-		"--wait",
-		"--timeout", opts.Timeout.String(),
-		"-f", valuesFilePath,
-	}
-
-	// Add fullnameOverride to ensure consistent release naming
-	helmArgs = append(helmArgs,
-		"--set", fmt.Sprintf("fullnameOverride=%s", releaseName),
-	)
+	helmArgs := helmUpgradeArgs(opts, valuesFilePath)
 
-	// Add additional --set values if provided
-	for key, value := range opts.SetValues {
-		helmArgs = append(helmArgs, "--set", fmt.Sprintf("%s=%s", key, value))
+	// An interrupted or failed install can still leave the release and the adapter subscription behind
+	recordResource(LedgerKindHelmRelease, releaseName, opts.Namespace)
+	if subscriptionID := adapterSubscriptionID(expandedContent); subscriptionID != "" {
+		recordResource(LedgerKindPubSubSubscription, subscriptionID, releaseName)
 	}
 
 	logger.Info("executing Helm command", "args", helmArgs)
@@ -183,6 +167,64 @@ func (h *Helper) DeployAdapter(ctx context.Context, opts AdapterDeploymentOption
 	return nil
 }
 
+// withDefaults checks the required fields of the options and fills in the default timeout
+func (opts AdapterDeploymentOptions) withDefaults() (AdapterDeploymentOptions, error) {
+	if opts.Namespace == "" {
+		return opts, fmt.Errorf("AdapterDeploymentOptions.Namespace is required")
+	}
+	if opts.ChartPath == "" {
+		return opts, fmt.Errorf("AdapterDeploymentOptions.ChartPath is required")
+	}
+	if opts.AdapterName == "" {
+		return opts, fmt.Errorf("AdapterDeploymentOptions.AdapterName is required")
+	}
+	if opts.ReleaseName == "" {
+		return opts, fmt.Errorf("AdapterDeploymentOptions.ReleaseName is required - use GenerateAdapterReleaseName() to create a unique name")
+	}
+
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+	return opts, nil
+}
+
+// helmUpgradeArgs returns the arguments of the helm upgrade --install command deploying an adapter
+// with the given values file
+func helmUpgradeArgs(opts AdapterDeploymentOptions, valuesFilePath string) []string {
+	// Build Helm command with single values file
+	helmArgs := []string{
+		"upgrade", "--install",
+		opts.ReleaseName,
+		opts.ChartPath,
+		"--namespace", opts.Namespace,
+		"--create-namespace",
+		"--wait",
+		"--timeout", opts.Timeout.String(),
+		"-f", valuesFilePath,
+	}
+
+	// Add fullnameOverride to ensure consistent release naming
+	helmArgs = append(helmArgs,
+		"--set", fmt.Sprintf("fullnameOverride=%s", opts.ReleaseName),
+	)
+
+	// Label the release so leaked test deployments can be told apart from preinstalled adapters
+	helmArgs = append(helmArgs,
+		"--labels", fmt.Sprintf("%s=%s", client.KeyE2EManagedBy, client.ManagedByTestFramework),
+	)
+
+	// Add additional --set values if provided, in a stable order
+	keys := make([]string, 0, len(opts.SetValues))
+	for key := range opts.SetValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		helmArgs = append(helmArgs, "--set", fmt.Sprintf("%s=%s", key, opts.SetValues[key]))
+	}
+	return helmArgs
+}
+
 // UninstallAdapter uninstalls an adapter using Helm uninstall
 // This is a common function that can be reused across test cases
 func (h *Helper) UninstallAdapter(ctx context.Context, releaseName, namespace string) error {
@@ -207,7 +249,8 @@ func (h *Helper) UninstallAdapter(ctx context.Context, releaseName, namespace st
 			logger.Info("adapter release not found, skipping uninstall", "release_name", releaseName)
 			// Clean up orphaned cluster-scoped resources even when release is not found
 			// This handles cases like interrupted installs or manual deletions
-			h.cleanupClusterScopedResources(ctx, releaseName)
+			_ = h.cleanupClusterScopedResources(ctx, releaseName)
+			h.releaseAdapter(ctx, releaseName)
 			return nil
 		}
 		logger.Error("helm uninstall failed", "error", err, "output", string(output))
@@ -220,24 +263,44 @@ func (h *Helper) UninstallAdapter(ctx context.Context, releaseName, namespace st
 
 	// Clean up any orphaned cluster-scoped resources (ClusterRoles, ClusterRoleBindings)
 	// These can be left behind if a previous test run failed or was interrupted
-	h.cleanupClusterScopedResources(ctx, releaseName)
+	_ = h.cleanupClusterScopedResources(ctx, releaseName)
+	h.releaseAdapter(ctx, releaseName)
 
 	return nil
 }
 
+// releaseAdapter marks an uninstalled release as released in the suite ledger and deletes the Pub/Sub
+// subscription recorded when it was deployed, nothing consumes it anymore.
+// Deletion errors are logged, the subscription then shows up in the AfterSuite leak check.
+func (h *Helper) releaseAdapter(ctx context.Context, releaseName string) {
+	suiteLedger.Release(LedgerKindHelmRelease, releaseName)
+	subscriptions := suiteLedger.unreleased(func(entry LedgerEntry) bool {
+		return entry.Kind == LedgerKindPubSubSubscription && entry.Owner == releaseName
+	})
+	for _, subscription := range subscriptions {
+		if err := h.DeletePubSubSubscription(ctx, subscription.Name); err != nil {
+			logger.Error("failed to delete adapter Pub/Sub subscription", "release_name", releaseName, "subscription", subscription.Name, "error", err)
+		}
+	}
+}
+
 // cleanupClusterScopedResources removes orphaned cluster-scoped resources that may be left
-// after Helm uninstall. This is a best-effort cleanup and logs errors without failing.
-func (h *Helper) cleanupClusterScopedResources(ctx context.Context, releaseName string) {
+// after Helm uninstall. Resources that do not exist are not an error; failed deletions are logged
+// and returned, callers cleaning up after an uninstall treat them as best-effort.
+func (h *Helper) cleanupClusterScopedResources(ctx context.Context, releaseName string) error {
 	cmdCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	var errs []error
+
 	// Try to delete ClusterRole
 	clusterRoleCmd := exec.CommandContext(cmdCtx, "kubectl", "delete", "clusterrole", releaseName,
 		"--ignore-not-found=true")
 	if output, err := clusterRoleCmd.CombinedOutput(); err != nil {
-		logger.Info("could not delete ClusterRole (may not exist)",
+		logger.Info("could not delete ClusterRole",
 			"release_name", releaseName,
 			"output", string(output))
+		errs = append(errs, fmt.Errorf("failed to delete ClusterRole %s: %w (output: %s)", releaseName, err, string(output)))
 	} else {
 		logger.Info("cleaned up ClusterRole", "release_name", releaseName)
 	}
@@ -246,12 +309,15 @@ func (h *Helper) cleanupClusterScopedResources(ctx context.Context, releaseName
 	clusterRoleBindingCmd := exec.CommandContext(cmdCtx, "kubectl", "delete", "clusterrolebinding", releaseName,
 		"--ignore-not-found=true")
 	if output, err := clusterRoleBindingCmd.CombinedOutput(); err != nil {
-		logger.Info("could not delete ClusterRoleBinding (may not exist)",
+		logger.Info("could not delete ClusterRoleBinding",
 			"release_name", releaseName,
 			"output", string(output))
+		errs = append(errs, fmt.Errorf("failed to delete ClusterRoleBinding %s: %w (output: %s)", releaseName, err, string(output)))
 	} else {
 		logger.Info("cleaned up ClusterRoleBinding", "release_name", releaseName)
 	}
+
+	return errors.Join(errs...)
 }
 
 // saveDiagnosticLogs saves diagnostic information when adapter deployment fails
@@ -270,6 +336,8 @@ func (h *Helper) saveDiagnosticLogs(ctx context.Context, adapterName, releaseNam
 		return
 	}
 
+	h.RecordArtifact(outputDir)
+
 	logger.Info("saving diagnostic logs",
 		"adapter_name", adapterName,
 		"release_name", releaseName,
@@ -427,12 +495,14 @@ func (h *Helper) DeletePubSubSubscription(ctx context.Context, subscriptionID st
 		outputStr := string(output)
 		if strings.Contains(outputStr, "NOT_FOUND") || strings.Contains(outputStr, "not found") {
 			logger.Info("Pub/Sub subscription not found, skipping deletion", "subscription", subscriptionID)
+			suiteLedger.Release(LedgerKindPubSubSubscription, subscriptionID)
 			return nil
 		}
 		return fmt.Errorf("failed to delete Pub/Sub subscription %s: %w (output: %s)", subscriptionID, err, outputStr)
 	}
 
 	logger.Info("Pub/Sub subscription deleted successfully", "subscription", subscriptionID)
+	suiteLedger.Release(LedgerKindPubSubSubscription, subscriptionID)
 	return nil
 }
 
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// envVarReference matches the $VAR and ${VAR} references envsubst expands
+var envVarReference = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// UnresolvedEnvVars returns the variables referenced in content that are not set in the environment, sorted.
+// envsubst silently expands them to empty strings.
+func UnresolvedEnvVars(content []byte) []string {
+	seen := make(map[string]bool)
+	var unresolved []string
+	for _, match := range envVarReference.FindAllSubmatch(content, -1) {
+		name := string(match[1]) + string(match[2])
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, ok := os.LookupEnv(name); !ok {
+			unresolved = append(unresolved, name)
+		}
+	}
+	sort.Strings(unresolved)
+	return unresolved
+}
+
+// AdapterDryRun is what DeployAdapter would do for an adapter
+type AdapterDryRun struct {
+	Values      []byte   // values.yaml expanded with envsubst
+	HelmCommand []string // helm command line, starting with "helm"
+}
+
+// DryRunAdapter expands the values.yaml of an adapter under testDataDir the way DeployAdapter does and
+// returns it with the Helm command DeployAdapter would run. Nothing is copied, deployed or recorded.
+// It fails on variables not set in the environment and on values that are not valid YAML once expanded.
+func DryRunAdapter(ctx context.Context, testDataDir string, opts AdapterDeploymentOptions) (AdapterDryRun, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return AdapterDryRun{}, err
+	}
+
+	sourceValues := filepath.Join(testDataDir, AdapterConfigsDir, opts.AdapterName, "values.yaml")
+	content, err := os.ReadFile(sourceValues) // #nosec G304 -- sourceValues is constructed from trusted config
+	if err != nil {
+		return AdapterDryRun{}, fmt.Errorf("failed to read values.yaml: %w", err)
+	}
+	if unresolved := UnresolvedEnvVars(content); len(unresolved) > 0 {
+		return AdapterDryRun{}, fmt.Errorf("%s references unset environment variables: %s", sourceValues, strings.Join(unresolved, ", "))
+	}
+
+	expanded, err := expandEnvVarsInYAMLToBytes(ctx, sourceValues)
+	if err != nil {
+		return AdapterDryRun{}, fmt.Errorf("failed to expand environment variables in %s: %w", sourceValues, err)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(expanded, &values); err != nil {
+		return AdapterDryRun{}, fmt.Errorf("%s is not valid YAML after expansion: %w", sourceValues, err)
+	}
+
+	// DeployAdapter runs Helm on the values.yaml it copied into the chart
+	valuesFilePath := filepath.Join(opts.ChartPath, opts.AdapterName, "values.yaml")
+	return AdapterDryRun{
+		Values:      expanded,
+		HelmCommand: append([]string{"helm"}, helmUpgradeArgs(opts, valuesFilePath)...),
+	}, nil
+}
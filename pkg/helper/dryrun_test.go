@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnresolvedEnvVars(t *testing.T) {
+	t.Setenv("E2E_DRYRUN_SET", "value")
+	t.Setenv("E2E_DRYRUN_EMPTY", "")
+
+	content := []byte(`registry: ${E2E_DRYRUN_SET}
+tag: $E2E_DRYRUN_EMPTY
+subscriptionId: ${E2E_DRYRUN_UNSET_B}-${E2E_DRYRUN_UNSET_A}
+topic: ${E2E_DRYRUN_UNSET_B}
+price: 5$
+`)
+	got := UnresolvedEnvVars(content)
+	want := []string{"E2E_DRYRUN_UNSET_A", "E2E_DRYRUN_UNSET_B"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnresolvedEnvVars() = %v, want %v", got, want)
+	}
+}
+
+func TestHelmUpgradeArgs(t *testing.T) {
+	opts, err := AdapterDeploymentOptions{
+		ReleaseName: "adapter-clusters-cl-job-ab12c",
+		Namespace:   "e2e",
+		ChartPath:   "/work/adapter/charts",
+		AdapterName: "cl-job",
+		SetValues:   map[string]string{"b": "2", "a": "1"},
+	}.withDefaults()
+	if err != nil {
+		t.Fatalf("withDefaults() error: %v", err)
+	}
+
+	got := strings.Join(helmUpgradeArgs(opts, "/work/adapter/charts/cl-job/values.yaml"), " ")
+	want := "upgrade --install adapter-clusters-cl-job-ab12c /work/adapter/charts --namespace e2e --create-namespace" +
+		" --wait --timeout 5m0s -f /work/adapter/charts/cl-job/values.yaml --set fullnameOverride=adapter-clusters-cl-job-ab12c" +
+		" --labels e2e.hyperfleet.io/managed-by=test-framework --set a=1 --set b=2"
+	if got != want {
+		t.Errorf("helmUpgradeArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestDryRunAdapterUnresolvedVariables(t *testing.T) {
+	testDataDir := t.TempDir()
+	adapterDir := filepath.Join(testDataDir, AdapterConfigsDir, "cl-job")
+	if err := os.MkdirAll(adapterDir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(adapterDir, "values.yaml"), []byte("tag: ${E2E_DRYRUN_UNSET}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := DryRunAdapter(context.Background(), testDataDir, AdapterDeploymentOptions{
+		ReleaseName: "adapter-clusters-cl-job-ab12c",
+		Namespace:   "e2e",
+		ChartPath:   "charts",
+		AdapterName: "cl-job",
+	})
+	if err == nil || !strings.Contains(err.Error(), "unset environment variables: E2E_DRYRUN_UNSET") {
+		t.Errorf("DryRunAdapter() error = %v, want the unset variable reported", err)
+	}
+}
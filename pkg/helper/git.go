@@ -78,8 +78,10 @@ func (h *Helper) CloneHelmChart(ctx context.Context, opts HelmChartCloneOptions)
 		if err := os.RemoveAll(componentDir); err != nil {
 			return fmt.Errorf("failed to remove cloned chart directory: %w", err)
 		}
+		suiteLedger.Release(LedgerKindChartDirectory, componentDir)
 		return nil
 	}
+	recordResource(LedgerKindChartDirectory, componentDir, "")
 
 	// Redact credentials from RepoURL before logging
 	redactedRepo := opts.RepoURL
@@ -3,9 +3,12 @@ package helper
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/onsi/ginkgo/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
@@ -14,6 +17,7 @@ import (
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client/maestro"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/report"
 )
 
 // Helper provides utility functions for e2e tests
@@ -22,6 +26,11 @@ type Helper struct {
 	Client        *client.HyperFleetClient
 	K8sClient     *k8sclient.Client
 	MaestroClient *maestro.Client
+
+	// httpClient carries the TLS settings of Client, shared with MaestroClient
+	httpClient *http.Client
+	// auth sets the configured credentials on requests of Client and MaestroClient, nil without auth
+	auth openapi.RequestEditorFn
 }
 
 // TestDataPath resolves a relative path within the testdata directory
@@ -30,6 +39,16 @@ func (h *Helper) TestDataPath(relativePath string) string {
 	return filepath.Join(h.Cfg.TestDataDir, relativePath)
 }
 
+// RecordArtifact attaches a file or directory saved under the output directory to the current spec,
+// so reports can link to it. Must be called from within a spec.
+func (h *Helper) RecordArtifact(path string) {
+	rel, err := filepath.Rel(h.Cfg.OutputDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = path
+	}
+	ginkgo.AddReportEntry(report.ReportEntryArtifact, rel, ginkgo.ReportEntryVisibilityFailureOrVerbose)
+}
+
 // GetTestCluster creates a new temporary test cluster
 func (h *Helper) GetTestCluster(ctx context.Context, payloadPath string) (string, error) {
 	cluster, err := h.Client.CreateClusterFromPayload(ctx, payloadPath)
@@ -172,6 +191,7 @@ func (h *Helper) CleanupTestCluster(ctx context.Context, clusterID string) error
 	}
 
 	logger.Info("successfully cleaned up cluster resources", "cluster_id", clusterID)
+	suiteLedger.Release(LedgerKindCluster, clusterID)
 	return nil
 }
 
@@ -182,14 +202,25 @@ func (h *Helper) GetTestNodePool(ctx context.Context, clusterID, payloadPath str
 
 // CleanupTestNodePool cleans up test nodepool
 func (h *Helper) CleanupTestNodePool(ctx context.Context, clusterID, nodepoolID string) error {
-	return h.Client.DeleteNodePool(ctx, clusterID, nodepoolID)
+	if err := h.Client.DeleteNodePool(ctx, clusterID, nodepoolID); err != nil {
+		return err
+	}
+	suiteLedger.Release(LedgerKindNodePool, nodepoolID)
+	return nil
 }
 
 // GetMaestroClient returns the Maestro client, initializing it lazily on first access
 // This avoids the overhead of K8s service discovery for test suites that don't use Maestro
 func (h *Helper) GetMaestroClient() *maestro.Client {
 	if h.MaestroClient == nil {
-		h.MaestroClient = maestro.NewClient("")
+		var opts []maestro.Option
+		if h.httpClient != nil {
+			opts = append(opts, maestro.WithHTTPClient(h.httpClient))
+		}
+		if h.auth != nil {
+			opts = append(opts, maestro.WithRequestEditorFn(h.auth))
+		}
+		h.MaestroClient = maestro.NewClient("", opts...)
 	}
 	return h.MaestroClient
 }
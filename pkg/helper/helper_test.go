@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+func TestGetMaestroClientAuth(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+	t.Setenv("MAESTRO_URL", server.URL)
+
+	h := &Helper{httpClient: server.Client(), auth: client.BearerAuth(client.StaticToken("s3cret"))}
+	if _, err := h.GetMaestroClient().GetResourceBundles(context.Background()); err != nil {
+		t.Fatalf("GetResourceBundles() error = %v", err)
+	}
+	if authorization != "Bearer s3cret" {
+		t.Errorf("Authorization = %q, want the configured token", authorization)
+	}
+}
+
+func TestNewHTTPClientsRecordRetriedAttempts(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	recorder := client.NewRecorder()
+	cfg := &config.Config{Retry: config.RetryConfig{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+	tokenHTTPClient, apiHTTPClient, err := newHTTPClients(cfg, recorder)
+	if err != nil {
+		t.Fatalf("newHTTPClients() error = %v", err)
+	}
+
+	resp, err := apiHTTPClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || recorder.Len() != 2 {
+		t.Errorf("status %d with %d recorded attempts, want 200 with the 503 and the retry recorded", resp.StatusCode, recorder.Len())
+	}
+
+	resp, err = tokenHTTPClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if recorder.Len() != 2 {
+		t.Errorf("recorded %d requests, want the token client left out of the recording", recorder.Len())
+	}
+}
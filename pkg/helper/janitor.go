@@ -0,0 +1,506 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+)
+
+// Resource kinds reported by the janitor
+const (
+	JanitorKindCluster            = "cluster"
+	JanitorKindNamespace          = "namespace"
+	JanitorKindResourceBundle     = "maestro resource bundle"
+	JanitorKindHelmRelease        = "helm release"
+	JanitorKindClusterRole        = "cluster role"
+	JanitorKindPubSubSubscription = "pubsub subscription"
+)
+
+// helmTimeLayout is the timestamp format used by `helm list -o json` for the "updated" field
+const helmTimeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// adapterReleaseNamePattern matches release names produced by GenerateAdapterReleaseName
+var adapterReleaseNamePattern = regexp.MustCompile(`^adapter-(clusters|nodepools)-[a-z0-9-]+-[a-z0-9]{5}$`)
+
+// testClusterNamePattern matches cluster names rendered from testdata/payloads/clusters/cluster-request.json,
+// which identify test clusters created before the framework labeled them
+var testClusterNamePattern = regexp.MustCompile(`^hp-cluster-[0-9a-f]{8}$`)
+
+// JanitorOptions controls which leaked resources the janitor considers and whether it deletes them
+type JanitorOptions struct {
+	// OlderThan is the minimum age of a resource before it is considered leaked
+	OlderThan time.Duration
+
+	// DryRun reports the leaked resources without deleting them
+	DryRun bool
+
+	// Subscriptions enables the Pub/Sub subscription cleanup. Subscriptions carry neither a creation time nor
+	// test-framework labels, so OlderThan does not apply to them and a subscription of an adapter a concurrent
+	// run is deploying may be deleted; only enable it when no other run is active.
+	Subscriptions bool
+}
+
+// JanitorItem is a single leaked resource found by the janitor
+type JanitorItem struct {
+	Kind    string
+	Name    string
+	Age     time.Duration
+	Deleted bool
+	Err     error
+
+	// Skipped is the reason the resource was left in place although it matched, empty when it was not skipped
+	Skipped string
+}
+
+// JanitorSummary holds every leaked resource found by the janitor and the outcome of its deletion
+type JanitorSummary struct {
+	Items []JanitorItem
+}
+
+// JanitorKindCounts holds the number of leaked resources of one kind and the outcome of their deletion
+type JanitorKindCounts struct {
+	Found   int
+	Deleted int
+	Failed  int
+	Skipped int
+}
+
+// Counts returns the found, deleted, failed and skipped item counts for each kind
+func (s *JanitorSummary) Counts() map[string]JanitorKindCounts {
+	counts := make(map[string]JanitorKindCounts)
+	for _, item := range s.Items {
+		c := counts[item.Kind]
+		c.Found++
+		if item.Deleted {
+			c.Deleted++
+		}
+		if item.Err != nil {
+			c.Failed++
+		}
+		if item.Skipped != "" {
+			c.Skipped++
+		}
+		counts[item.Kind] = c
+	}
+	return counts
+}
+
+// Errors returns all deletion errors collected by the janitor
+func (s *JanitorSummary) Errors() []error {
+	var errs []error
+	for _, item := range s.Items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", item.Kind, item.Name, item.Err))
+		}
+	}
+	return errs
+}
+
+func (s *JanitorSummary) add(item JanitorItem) {
+	s.Items = append(s.Items, item)
+}
+
+// CleanupLeakedResources finds resources left behind by interrupted test runs and deletes them.
+// Resources are identified by the labels and naming conventions used by the test framework:
+//   - clusters labeled e2e.hyperfleet.io/managed-by=test-framework or e2e.hyperfleet.io/run-id in the API, or
+//     named like the test payload (hp-cluster-<hex>), whose Maestro resource bundles and namespaces are removed
+//     through CleanupTestCluster
+//   - Helm releases in the test namespace named adapter-<type>-<name>-<suffix> and labeled by DeployAdapter,
+//     removed through UninstallAdapter
+//   - ClusterRoles/ClusterRoleBindings with the same naming but no installed release
+//   - with opts.Subscriptions, Pub/Sub subscriptions of test adapters (testdata/adapter-configs) without a
+//     live release
+//
+// Like CleanupTestCluster, it continues after individual failures and records them in the summary.
+func (h *Helper) CleanupLeakedResources(ctx context.Context, opts JanitorOptions) *JanitorSummary {
+	summary := &JanitorSummary{}
+	cutoff := time.Now().Add(-opts.OlderThan)
+
+	logger.Info("searching for leaked test resources", "older_than", opts.OlderThan, "dry_run", opts.DryRun)
+
+	// Uninstall leaked adapters first so they stop reconciling resources of the clusters removed below
+	liveReleases, err := h.cleanupLeakedReleases(ctx, cutoff, opts.DryRun, summary)
+	if err != nil {
+		// Without the list of live releases we cannot tell orphaned resources apart from in-use ones
+		logger.Error("skipping cluster role and Pub/Sub cleanup", "error", err)
+	} else {
+		h.cleanupLeakedClusterRoles(ctx, cutoff, liveReleases, opts.DryRun, summary)
+		if opts.Subscriptions {
+			h.cleanupLeakedSubscriptions(ctx, liveReleases, opts.DryRun, summary)
+		} else {
+			logger.Info("skipping Pub/Sub subscription cleanup, it is not bounded by age and must be enabled explicitly")
+		}
+	}
+
+	h.cleanupLeakedClusters(ctx, cutoff, opts.DryRun, summary)
+
+	logger.Info("leaked resource cleanup finished", "items", len(summary.Items), "errors", len(summary.Errors()))
+	return summary
+}
+
+// cleanupLeakedClusters cleans up the Maestro resource bundles and namespaces of clusters created by the test
+// framework. The cluster ID labels of the resources are looked up in the API: only test clusters (see
+// testClusterCreated) created before cutoff are cleaned up, resources of other clusters are reported as skipped.
+func (h *Helper) cleanupLeakedClusters(ctx context.Context, cutoff time.Time, dryRun bool, summary *JanitorSummary) {
+	// resources found for each cluster ID, added to the summary once the cluster is classified
+	resources := make(map[string][]JanitorItem)
+
+	if maestroClient := h.GetMaestroClient(); maestroClient != nil {
+		rbs, err := maestroClient.GetResourceBundles(ctx)
+		if err != nil {
+			logger.Error("failed to list maestro resource bundles", "error", err)
+			summary.add(JanitorItem{Kind: JanitorKindResourceBundle, Name: "*", Err: err})
+		} else {
+			for _, rb := range rbs.Items {
+				if clusterID := rb.Metadata.Labels[client.KeyClusterID]; clusterID != "" {
+					resources[clusterID] = append(resources[clusterID],
+						JanitorItem{Kind: JanitorKindResourceBundle, Name: rb.ID, Age: time.Since(rb.CreatedAt)})
+				}
+			}
+		}
+	}
+
+	namespaces, err := h.K8sClient.FetchNamespacesByLabelSelector(ctx, client.KeyClusterID)
+	if err != nil {
+		logger.Error("failed to list cluster namespaces", "error", err)
+		summary.add(JanitorItem{Kind: JanitorKindNamespace, Name: "*", Err: err})
+	} else {
+		for _, ns := range namespaces {
+			clusterID := ns.Labels[client.KeyClusterID]
+			resources[clusterID] = append(resources[clusterID],
+				JanitorItem{Kind: JanitorKindNamespace, Name: ns.Name, Age: time.Since(ns.CreationTimestamp.Time)})
+		}
+	}
+
+	if len(resources) == 0 {
+		return
+	}
+
+	// Without the cluster labels the resources cannot be attributed to test runs, nothing is deleted
+	clusters, err := h.Client.ListClusters(ctx)
+	if err != nil {
+		logger.Error("failed to list clusters", "error", err)
+		summary.add(JanitorItem{Kind: JanitorKindCluster, Name: "*", Err: err})
+		return
+	}
+	byID := make(map[string]openapi.Cluster, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		if cluster.Id != nil {
+			byID[*cluster.Id] = cluster
+		}
+	}
+
+	clusterIDs := make([]string, 0, len(resources))
+	for clusterID := range resources {
+		clusterIDs = append(clusterIDs, clusterID)
+	}
+	sort.Strings(clusterIDs)
+
+	for _, clusterID := range clusterIDs {
+		item := JanitorItem{Kind: JanitorKindCluster, Name: clusterID}
+		cluster, found := byID[clusterID]
+		createdAt, isTestCluster := testClusterCreated(cluster)
+		switch {
+		case !found:
+			item.Skipped = "not found in the API"
+		case !isTestCluster:
+			item.Skipped = "not created by the test framework"
+		case createdAt.After(cutoff):
+			// Possibly still in use by a running test
+			continue
+		default:
+			item.Age = time.Since(createdAt)
+			if !dryRun {
+				// CleanupTestCluster removes both the resource bundles and the namespaces of the cluster
+				item.Err = h.CleanupTestCluster(ctx, clusterID)
+				item.Deleted = item.Err == nil
+			}
+		}
+
+		for _, resource := range resources[clusterID] {
+			resource.Deleted = item.Deleted
+			resource.Skipped = item.Skipped
+			summary.add(resource)
+		}
+		summary.add(item)
+	}
+}
+
+// testClusterCreated returns the creation time of a cluster created by the test framework, taken from its
+// e2e.hyperfleet.io/created-at label or else its API creation time. Test clusters carry the
+// e2e.hyperfleet.io/managed-by=test-framework or e2e.hyperfleet.io/run-id label; clusters of runs that predate
+// the labels are recognized by the name of the test payload. It reports false for any other cluster.
+func testClusterCreated(cluster openapi.Cluster) (time.Time, bool) {
+	var labels map[string]string
+	if cluster.Labels != nil {
+		labels = *cluster.Labels
+	}
+	if labels[client.KeyE2EManagedBy] != client.ManagedByTestFramework && labels[client.KeyE2ERunID] == "" &&
+		!testClusterNamePattern.MatchString(cluster.Name) {
+		return time.Time{}, false
+	}
+	if createdAt, err := time.Parse(client.CreatedAtFormat, labels[client.KeyE2ECreatedAt]); err == nil {
+		return createdAt, true
+	}
+	return cluster.CreatedTime, true
+}
+
+// helmRelease is the subset of `helm list -o json` output used by the janitor
+type helmRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Updated   string `json:"updated"`
+	Status    string `json:"status"`
+}
+
+// cleanupLeakedReleases uninstalls old adapter releases deployed by tests in the test namespace.
+// Only releases labeled by DeployAdapter are removed; preinstalled adapters share the same naming scheme.
+// Returns the names of the adapter releases that remain installed
+func (h *Helper) cleanupLeakedReleases(ctx context.Context, cutoff time.Time, dryRun bool, summary *JanitorSummary) (map[string]bool, error) {
+	all, err := h.listHelmReleases(ctx, "")
+	var testDeployed []helmRelease
+	if err == nil {
+		testDeployed, err = h.listHelmReleases(ctx, client.KeyE2EManagedBy+"="+client.ManagedByTestFramework)
+	}
+	if err != nil {
+		logger.Error("failed to list helm releases", "namespace", h.Cfg.Namespace, "error", err)
+		summary.add(JanitorItem{Kind: JanitorKindHelmRelease, Name: "*", Err: err})
+		return nil, err
+	}
+	releases := filterLeakedReleases(all, testDeployed, cutoff)
+
+	live := make(map[string]bool)
+	for _, release := range releases.live {
+		live[release.Name] = true
+	}
+
+	for _, release := range releases.leaked {
+		updated, _ := time.Parse(helmTimeLayout, release.Updated)
+		item := JanitorItem{Kind: JanitorKindHelmRelease, Name: release.Name, Age: time.Since(updated)}
+		if dryRun {
+			live[release.Name] = true
+		} else {
+			item.Err = h.UninstallAdapter(ctx, release.Name, release.Namespace)
+			item.Deleted = item.Err == nil
+			if !item.Deleted {
+				live[release.Name] = true
+			}
+		}
+		summary.add(item)
+	}
+
+	return live, nil
+}
+
+// helmReleaseSet splits adapter releases into the ones still in use and the leaked ones
+type helmReleaseSet struct {
+	live   []helmRelease
+	leaked []helmRelease
+}
+
+// filterLeakedReleases classifies releases: test-deployed adapter releases last updated before cutoff are leaked,
+// every other release (preinstalled, recent, or with an unparsable timestamp) is live
+func filterLeakedReleases(all, testDeployed []helmRelease, cutoff time.Time) helmReleaseSet {
+	isTestDeployed := make(map[string]bool, len(testDeployed))
+	for _, release := range testDeployed {
+		isTestDeployed[release.Name] = true
+	}
+
+	var set helmReleaseSet
+	for _, release := range all {
+		updated, err := time.Parse(helmTimeLayout, release.Updated)
+		if isTestDeployed[release.Name] && adapterReleaseNamePattern.MatchString(release.Name) &&
+			err == nil && updated.Before(cutoff) {
+			set.leaked = append(set.leaked, release)
+		} else {
+			set.live = append(set.live, release)
+		}
+	}
+	return set
+}
+
+// listHelmReleases lists all releases, including failed and pending ones, in the test namespace
+// An optional selector filters releases by their Helm release labels
+func (h *Helper) listHelmReleases(ctx context.Context, selector string) ([]helmRelease, error) {
+	cmdCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	helmArgs := []string{"list",
+		"-n", h.Cfg.Namespace,
+		"--all",
+		"--max", "0",
+		"-o", "json",
+	}
+	if selector != "" {
+		helmArgs = append(helmArgs, "--selector", selector)
+	}
+
+	cmd := exec.CommandContext(cmdCtx, "helm", helmArgs...) // #nosec G204 -- namespace and selector are from trusted config
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("helm list failed: %w", err)
+	}
+
+	var releases []helmRelease
+	if err := json.Unmarshal(output, &releases); err != nil {
+		return nil, fmt.Errorf("failed to decode helm list output: %w", err)
+	}
+	return releases, nil
+}
+
+// cleanupLeakedClusterRoles removes old ClusterRoles/ClusterRoleBindings named after adapter releases that no longer exist
+func (h *Helper) cleanupLeakedClusterRoles(ctx context.Context, cutoff time.Time, liveReleases map[string]bool, dryRun bool, summary *JanitorSummary) {
+	orphans := make(map[string]time.Time)
+
+	roles, err := h.K8sClient.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("failed to list cluster roles", "error", err)
+		summary.add(JanitorItem{Kind: JanitorKindClusterRole, Name: "*", Err: err})
+		return
+	}
+	for _, role := range roles.Items {
+		orphans[role.Name] = role.CreationTimestamp.Time
+	}
+
+	bindings, err := h.K8sClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Error("failed to list cluster role bindings", "error", err)
+		summary.add(JanitorItem{Kind: JanitorKindClusterRole, Name: "*", Err: err})
+		return
+	}
+	for _, binding := range bindings.Items {
+		if _, ok := orphans[binding.Name]; !ok {
+			orphans[binding.Name] = binding.CreationTimestamp.Time
+		}
+	}
+
+	names := make([]string, 0, len(orphans))
+	for name, created := range orphans {
+		if adapterReleaseNamePattern.MatchString(name) && !liveReleases[name] && created.Before(cutoff) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		item := JanitorItem{Kind: JanitorKindClusterRole, Name: name, Age: time.Since(orphans[name])}
+		if !dryRun {
+			item.Err = h.cleanupClusterScopedResources(ctx, name)
+			item.Deleted = item.Err == nil
+		}
+		summary.add(item)
+	}
+}
+
+// pubSubSubscription is the subset of `gcloud pubsub subscriptions list` output used by the janitor
+type pubSubSubscription struct {
+	Name string `json:"name"`
+}
+
+// cleanupLeakedSubscriptions deletes Pub/Sub subscriptions of test adapters that have no live release.
+// Subscriptions are named <namespace>-<resource_type>-<adapter_name>; Pub/Sub does not expose a creation
+// time, so a subscription is considered leaked when no adapter release for it is installed, regardless of age.
+func (h *Helper) cleanupLeakedSubscriptions(ctx context.Context, liveReleases map[string]bool, dryRun bool, summary *JanitorSummary) {
+	entries, err := os.ReadDir(filepath.Join(h.Cfg.TestDataDir, AdapterConfigsDir))
+	if err != nil {
+		logger.Info("no test adapter configs found, skipping Pub/Sub cleanup", "error", err)
+		return
+	}
+
+	candidates := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		adapterName := entry.Name()
+		if slices.Contains(h.Cfg.Adapters.Cluster, adapterName) || slices.Contains(h.Cfg.Adapters.NodePool, adapterName) {
+			// Required adapters are preinstalled and own their subscriptions
+			continue
+		}
+		for _, resourceType := range []string{ResourceTypeClusters, ResourceTypeNodepools} {
+			if hasLiveRelease(liveReleases, resourceType, adapterName) {
+				continue
+			}
+			candidates[h.Cfg.Namespace+"-"+resourceType+"-"+adapterName] = adapterName
+		}
+	}
+
+	existing, err := h.listPubSubSubscriptions(ctx)
+	if err != nil {
+		logger.Error("failed to list Pub/Sub subscriptions", "error", err)
+		summary.add(JanitorItem{Kind: JanitorKindPubSubSubscription, Name: "*", Err: err})
+		return
+	}
+
+	for _, subscriptionID := range existing {
+		if _, ok := candidates[subscriptionID]; !ok {
+			continue
+		}
+		item := JanitorItem{Kind: JanitorKindPubSubSubscription, Name: subscriptionID}
+		if !dryRun {
+			item.Err = h.DeletePubSubSubscription(ctx, subscriptionID)
+			item.Deleted = item.Err == nil
+		}
+		summary.add(item)
+	}
+}
+
+// hasLiveRelease reports whether an adapter release for the given resource type and adapter is still installed
+func hasLiveRelease(liveReleases map[string]bool, resourceType, adapterName string) bool {
+	base := adapterReleaseBaseName(resourceType, adapterName)
+	for name := range liveReleases {
+		// The suffix length differs between tests and deploy-scripts, but never contains a dash,
+		// so adapter names sharing a prefix do not match
+		suffix, ok := strings.CutPrefix(name, base+"-")
+		if ok && suffix != "" && !strings.Contains(suffix, "-") {
+			return true
+		}
+	}
+	return false
+}
+
+// listPubSubSubscriptions returns the IDs of the Pub/Sub subscriptions prefixed with the test namespace
+func (h *Helper) listPubSubSubscriptions(ctx context.Context) ([]string, error) {
+	projectID := h.Cfg.GCPProjectID
+	if projectID == "" {
+		projectID = defaultGCPProjectID
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, "gcloud", "pubsub", "subscriptions", "list", // #nosec G204 -- projectID and namespace are from trusted test config
+		"--project="+projectID,
+		"--filter=name:"+h.Cfg.Namespace+"-",
+		"--format=json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gcloud pubsub subscriptions list failed: %w", err)
+	}
+
+	var subscriptions []pubSubSubscription
+	if err := json.Unmarshal(output, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to decode gcloud output: %w", err)
+	}
+
+	ids := make([]string, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		// Names are returned as projects/<project>/subscriptions/<id>
+		ids = append(ids, s.Name[strings.LastIndex(s.Name, "/")+1:])
+	}
+	return ids, nil
+}
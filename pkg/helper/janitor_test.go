@@ -0,0 +1,166 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+)
+
+func TestAdapterReleaseNamePattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		releaseName string
+		want        bool
+	}{
+		{
+			name:        "generated cluster adapter release",
+			releaseName: GenerateAdapterReleaseName(ResourceTypeClusters, "cl-invalid-resource"),
+			want:        true,
+		},
+		{
+			name:        "generated nodepool adapter release",
+			releaseName: GenerateAdapterReleaseName(ResourceTypeNodepools, "np-configmap"),
+			want:        true,
+		},
+		{
+			name:        "generated release with truncated base",
+			releaseName: GenerateAdapterReleaseName(ResourceTypeClusters, "a-very-long-adapter-name-that-needs-truncation"),
+			want:        true,
+		},
+		{
+			name:        "preinstalled adapter release",
+			releaseName: "hyperfleet-adapter-cl-namespace",
+			want:        false,
+		},
+		{
+			name:        "release installed by deploy-scripts",
+			releaseName: "adapter-clusters-cl-namespace-a1b2c3d4",
+			want:        false,
+		},
+		{
+			name:        "unknown resource type",
+			releaseName: "adapter-machines-cl-job-abcde",
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adapterReleaseNamePattern.MatchString(tt.releaseName); got != tt.want {
+				t.Errorf("adapterReleaseNamePattern.MatchString(%q) = %v, want %v", tt.releaseName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasLiveRelease(t *testing.T) {
+	live := map[string]bool{
+		"adapter-clusters-cl-precondition-error-x1y2z": true,
+		"adapter-clusters-cl-namespace-a1b2c3d4":       true,
+	}
+
+	if !hasLiveRelease(live, ResourceTypeClusters, "cl-precondition-error") {
+		t.Errorf("expected live release for cl-precondition-error")
+	}
+	if hasLiveRelease(live, ResourceTypeNodepools, "cl-precondition-error") {
+		t.Errorf("expected no live nodepool release for cl-precondition-error")
+	}
+	if hasLiveRelease(live, ResourceTypeClusters, "cl-precondition") {
+		t.Errorf("expected adapter name prefix not to match a longer adapter name")
+	}
+	if !hasLiveRelease(live, ResourceTypeClusters, "cl-namespace") {
+		t.Errorf("expected live release for preinstalled cl-namespace with deploy-scripts suffix")
+	}
+}
+
+func TestFilterLeakedReleases(t *testing.T) {
+	cutoff := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := "2025-01-01 08:00:00.000000000 +0000 UTC"
+	recent := "2025-01-01 13:00:00.000000000 +0000 UTC"
+
+	preinstalled := helmRelease{Name: "adapter-clusters-cl-namespace-a1b2c3d4", Updated: old}
+	leaked := helmRelease{Name: "adapter-clusters-cl-invalid-resource-x1y2z", Updated: old}
+	running := helmRelease{Name: "adapter-clusters-cl-precondition-error-k3l4m", Updated: recent}
+	unrelated := helmRelease{Name: "hyperfleet-api", Updated: old}
+
+	set := filterLeakedReleases(
+		[]helmRelease{preinstalled, leaked, running, unrelated},
+		[]helmRelease{leaked, running},
+		cutoff,
+	)
+
+	if len(set.leaked) != 1 || set.leaked[0].Name != leaked.Name {
+		t.Errorf("leaked = %v, want only %s", set.leaked, leaked.Name)
+	}
+	if len(set.live) != 3 || set.live[0].Name != preinstalled.Name || set.live[1].Name != running.Name {
+		t.Errorf("live = %v, want %s, %s and %s", set.live, preinstalled.Name, running.Name, unrelated.Name)
+	}
+}
+
+func TestJanitorSummaryCounts(t *testing.T) {
+	summary := &JanitorSummary{}
+	summary.add(JanitorItem{Kind: JanitorKindNamespace, Name: "ns-1", Age: time.Hour, Deleted: true})
+	summary.add(JanitorItem{Kind: JanitorKindNamespace, Name: "ns-2", Age: time.Hour, Err: errors.New("delete failed")})
+	summary.add(JanitorItem{Kind: JanitorKindHelmRelease, Name: "adapter-clusters-cl-job-abcde"})
+	summary.add(JanitorItem{Kind: JanitorKindCluster, Name: "c-1", Skipped: "not labeled by the test framework"})
+
+	counts := summary.Counts()
+	if got := counts[JanitorKindNamespace]; got != (JanitorKindCounts{Found: 2, Deleted: 1, Failed: 1}) {
+		t.Errorf("unexpected namespace counts: %+v", got)
+	}
+	if got := counts[JanitorKindHelmRelease]; got != (JanitorKindCounts{Found: 1}) {
+		t.Errorf("unexpected helm release counts: %+v", got)
+	}
+	if got := counts[JanitorKindCluster]; got != (JanitorKindCounts{Found: 1, Skipped: 1}) {
+		t.Errorf("unexpected cluster counts: %+v", got)
+	}
+	if errs := summary.Errors(); len(errs) != 1 {
+		t.Errorf("expected 1 error, got %d", len(errs))
+	}
+}
+
+func TestTestClusterCreated(t *testing.T) {
+	apiCreated := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	labeled := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		cluster  string
+		labels   map[string]string
+		want     time.Time
+		wantTest bool
+	}{
+		{name: "no labels"},
+		{name: "unrelated labels", labels: map[string]string{"team": "hyperfleet"}},
+		{name: "other manager", labels: map[string]string{client.KeyE2EManagedBy: "someone-else"}},
+		{name: "similar name", cluster: "hp-cluster-production"},
+		{name: "test payload name without labels", cluster: "hp-cluster-728d5ee0", want: apiCreated, wantTest: true},
+		{
+			name: "run ID with created-at",
+			labels: map[string]string{
+				client.KeyE2ERunID: "20250101-080000-abcdef", client.KeyE2ECreatedAt: labeled.Format(client.CreatedAtFormat),
+			},
+			want: labeled, wantTest: true,
+		},
+		{
+			name:   "managed by the test framework without created-at",
+			labels: map[string]string{client.KeyE2EManagedBy: client.ManagedByTestFramework},
+			want:   apiCreated, wantTest: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := openapi.Cluster{Name: tt.cluster, CreatedTime: apiCreated}
+			if tt.labels != nil {
+				cluster.Labels = &tt.labels
+			}
+			got, isTest := testClusterCreated(cluster)
+			if isTest != tt.wantTest || !got.Equal(tt.want) {
+				t.Errorf("testClusterCreated() = %v, %t, want %v, %t", got, isTest, tt.want, tt.wantTest)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/logger"
+)
+
+// Resource kinds recorded in the ledger, named like the janitor kinds
+const (
+	LedgerKindCluster            = JanitorKindCluster
+	LedgerKindNodePool           = "nodepool"
+	LedgerKindHelmRelease        = JanitorKindHelmRelease
+	LedgerKindChartDirectory     = "chart directory"
+	LedgerKindPubSubSubscription = JanitorKindPubSubSubscription
+)
+
+// Leak check modes controlling how the AfterSuite reports unreleased ledger entries
+const (
+	LeakCheckFail = "fail" // fail the suite
+	LeakCheckWarn = "warn" // log a warning only
+)
+
+// ValidateLeakCheck checks a leak check mode
+func ValidateLeakCheck(mode string) error {
+	if mode != LeakCheckFail && mode != LeakCheckWarn {
+		return fmt.Errorf("invalid leak check mode %q (must be %s or %s)", mode, LeakCheckFail, LeakCheckWarn)
+	}
+	return nil
+}
+
+// LedgerEntry is a resource created through a Helper
+type LedgerEntry struct {
+	Kind string
+	Name string
+	// Owner is the cluster ID of a nodepool, the namespace of a Helm release and the
+	// release of the adapter consuming a Pub/Sub subscription
+	Owner string
+	// Spec is the full text of the spec that created the resource, empty outside of specs
+	Spec     string
+	Released bool
+}
+
+func (e LedgerEntry) String() string {
+	switch {
+	case e.Owner == "":
+		return e.Kind + " " + e.Name
+	case e.Kind == LedgerKindNodePool:
+		return fmt.Sprintf("%s %s (cluster %s)", e.Kind, e.Name, e.Owner)
+	case e.Kind == LedgerKindHelmRelease:
+		return fmt.Sprintf("%s %s (namespace %s)", e.Kind, e.Name, e.Owner)
+	default:
+		return fmt.Sprintf("%s %s (release %s)", e.Kind, e.Name, e.Owner)
+	}
+}
+
+// Ledger records the resources created through helpers and whether they were released
+// It is safe for concurrent use, specs may create resources from several goroutines.
+type Ledger struct {
+	mu      sync.Mutex
+	entries []*LedgerEntry
+}
+
+// suiteLedger is shared by all helpers of the test process
+var suiteLedger = &Ledger{}
+
+// SuiteLedger returns the ledger of the resources created by the specs of this process
+func SuiteLedger() *Ledger {
+	return suiteLedger
+}
+
+// Record adds a resource to the ledger
+func (l *Ledger) Record(entry LedgerEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, &entry)
+}
+
+// Release marks every unreleased entry of the given kind and name as released, along with the
+// nodepools of a released cluster
+func (l *Ledger) Release(kind, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range l.entries {
+		if entry.Kind == kind && entry.Name == name ||
+			kind == LedgerKindCluster && entry.Kind == LedgerKindNodePool && entry.Owner == name {
+			entry.Released = true
+		}
+	}
+}
+
+// Entries returns all entries, in recording order
+func (l *Ledger) Entries() []LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]LedgerEntry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		entries = append(entries, *entry)
+	}
+	return entries
+}
+
+// Unreleased returns the entries not released yet, in recording order
+func (l *Ledger) Unreleased() []LedgerEntry {
+	return l.unreleased(func(LedgerEntry) bool { return true })
+}
+
+func (l *Ledger) unreleased(match func(LedgerEntry) bool) []LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var entries []LedgerEntry
+	for _, entry := range l.entries {
+		if !entry.Released && match(*entry) {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries
+}
+
+// released reports whether no entry of the given kind and name is left unreleased
+func (l *Ledger) released(kind, name string) bool {
+	return len(l.unreleased(func(entry LedgerEntry) bool { return entry.Kind == kind && entry.Name == name })) == 0
+}
+
+// recordResource adds a resource created by the current spec to the suite ledger
+func recordResource(kind, name, owner string) {
+	suiteLedger.Record(LedgerEntry{Kind: kind, Name: name, Owner: owner, Spec: ginkgo.CurrentSpecReport().FullText()})
+}
+
+// Leak is a ledger entry its spec did not release and the outcome of the final cleanup
+type Leak struct {
+	LedgerEntry
+	Err error
+}
+
+// leakCleanupOrder releases adapters before the clusters they reconcile, like CleanupLeakedResources
+var leakCleanupOrder = map[string]int{
+	LedgerKindHelmRelease:        0,
+	LedgerKindPubSubSubscription: 1,
+	LedgerKindNodePool:           2,
+	LedgerKindCluster:            3,
+	LedgerKindChartDirectory:     4,
+}
+
+// CleanupLeaks releases the unreleased entries of the suite ledger and returns them with the outcome
+// of their cleanup. It continues after individual failures, like CleanupLeakedResources.
+func (h *Helper) CleanupLeaks(ctx context.Context) []Leak {
+	entries := suiteLedger.Unreleased()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return leakCleanupOrder[entries[i].Kind] < leakCleanupOrder[entries[j].Kind]
+	})
+
+	leaks := make([]Leak, 0, len(entries))
+	for _, entry := range entries {
+		leak := Leak{LedgerEntry: entry}
+		if suiteLedger.released(entry.Kind, entry.Name) {
+			// Released along with an earlier entry, e.g. the subscription of an uninstalled adapter
+			leaks = append(leaks, leak)
+			continue
+		}
+		logger.Info("cleaning up leaked resource", "kind", entry.Kind, "name", entry.Name, "spec", entry.Spec)
+		switch entry.Kind {
+		case LedgerKindHelmRelease:
+			leak.Err = h.UninstallAdapter(ctx, entry.Name, entry.Owner)
+		case LedgerKindPubSubSubscription:
+			leak.Err = h.DeletePubSubSubscription(ctx, entry.Name)
+		case LedgerKindNodePool:
+			leak.Err = h.CleanupTestNodePool(ctx, entry.Owner, entry.Name)
+		case LedgerKindCluster:
+			leak.Err = h.CleanupTestCluster(ctx, entry.Name)
+		case LedgerKindChartDirectory:
+			if leak.Err = os.RemoveAll(entry.Name); leak.Err == nil {
+				suiteLedger.Release(entry.Kind, entry.Name)
+			}
+		}
+		if leak.Err != nil {
+			logger.Error("failed to clean up leaked resource", "kind", entry.Kind, "name", entry.Name, "error", leak.Err)
+		}
+		leaks = append(leaks, leak)
+	}
+	return leaks
+}
+
+// FormatLeaks lists leaked resources grouped by the spec that created them
+func FormatLeaks(leaks []Leak) string {
+	bySpec := make(map[string][]Leak)
+	var specs []string
+	for _, leak := range leaks {
+		spec := leak.Spec
+		if spec == "" {
+			spec = "(outside of specs)"
+		}
+		if _, ok := bySpec[spec]; !ok {
+			specs = append(specs, spec)
+		}
+		bySpec[spec] = append(bySpec[spec], leak)
+	}
+	sort.Strings(specs)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d resources created by specs were not released:\n", len(leaks))
+	for _, spec := range specs {
+		fmt.Fprintf(&b, "  %s\n", spec)
+		for _, leak := range bySpec[spec] {
+			outcome := "cleaned up by the suite"
+			if leak.Err != nil {
+				outcome = "final cleanup failed: " + leak.Err.Error()
+			}
+			fmt.Fprintf(&b, "    - %s: %s\n", leak.LedgerEntry, outcome)
+		}
+	}
+	return b.String()
+}
+
+// adapterSubscriptionID returns the Pub/Sub subscription an adapter consumes, from its expanded values.yaml
+// Placeholder IDs of adapters that are not deployed by tests are ignored.
+func adapterSubscriptionID(values []byte) string {
+	var parsed struct {
+		Broker struct {
+			GooglePubSub struct {
+				SubscriptionID string `json:"subscriptionId"`
+			} `json:"googlepubsub"`
+		} `json:"broker"`
+	}
+	if err := yaml.Unmarshal(values, &parsed); err != nil {
+		return ""
+	}
+	id := parsed.Broker.GooglePubSub.SubscriptionID
+	if id == "CHANGE_ME" {
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,112 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLedgerRelease(t *testing.T) {
+	ledger := &Ledger{}
+	ledger.Record(LedgerEntry{Kind: LedgerKindCluster, Name: "c1"})
+	ledger.Record(LedgerEntry{Kind: LedgerKindNodePool, Name: "np1", Owner: "c1"})
+	ledger.Record(LedgerEntry{Kind: LedgerKindCluster, Name: "c2"})
+	ledger.Record(LedgerEntry{Kind: LedgerKindHelmRelease, Name: "adapter-clusters-cl-job-ab12c", Owner: "hyperfleet"})
+	// Two specs deploying the same adapter share its subscription
+	ledger.Record(LedgerEntry{Kind: LedgerKindPubSubSubscription, Name: "hyperfleet-clusters-cl-job", Spec: "first"})
+	ledger.Record(LedgerEntry{Kind: LedgerKindPubSubSubscription, Name: "hyperfleet-clusters-cl-job", Spec: "second"})
+
+	// Releasing a cluster releases its nodepools
+	ledger.Release(LedgerKindCluster, "c1")
+	ledger.Release(LedgerKindPubSubSubscription, "hyperfleet-clusters-cl-job")
+	// Same name, other kind
+	ledger.Release(LedgerKindCluster, "adapter-clusters-cl-job-ab12c")
+
+	var got []string
+	for _, entry := range ledger.Unreleased() {
+		got = append(got, entry.String())
+	}
+	want := []string{"cluster c2", "helm release adapter-clusters-cl-job-ab12c (namespace hyperfleet)"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Unreleased() = %q, want %q", got, want)
+	}
+	if !ledger.released(LedgerKindCluster, "c1") || ledger.released(LedgerKindCluster, "c2") {
+		t.Error("released() does not match the entries released above")
+	}
+	if entries := ledger.Entries(); len(entries) != 6 || !entries[0].Released || entries[2].Released {
+		t.Errorf("Entries() = %+v, want all 6 entries with their released state", entries)
+	}
+}
+
+func TestCleanupLeaksRemovesChartDirectories(t *testing.T) {
+	saved := suiteLedger
+	suiteLedger = &Ledger{}
+	t.Cleanup(func() { suiteLedger = saved })
+
+	dir := filepath.Join(t.TempDir(), "adapter-1234")
+	if err := os.MkdirAll(filepath.Join(dir, "charts"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	suiteLedger.Record(LedgerEntry{Kind: LedgerKindChartDirectory, Name: dir, Spec: "deploys an adapter"})
+
+	leaks := (&Helper{}).CleanupLeaks(context.Background())
+	if len(leaks) != 1 || leaks[0].Err != nil {
+		t.Fatalf("CleanupLeaks() = %+v, want the chart directory cleaned up", leaks)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("chart directory still exists: %v", err)
+	}
+	if unreleased := suiteLedger.Unreleased(); len(unreleased) != 0 {
+		t.Errorf("Unreleased() after cleanup = %+v, want none", unreleased)
+	}
+}
+
+func TestFormatLeaks(t *testing.T) {
+	leaks := []Leak{
+		{LedgerEntry: LedgerEntry{Kind: LedgerKindCluster, Name: "c2", Spec: "[Suite: cluster] creates"}},
+		{LedgerEntry: LedgerEntry{Kind: LedgerKindChartDirectory, Name: "/work/adapter-1"}},
+		{
+			LedgerEntry: LedgerEntry{Kind: LedgerKindNodePool, Name: "np1", Owner: "c2", Spec: "[Suite: cluster] creates"},
+			Err:         errors.New("boom"),
+		},
+	}
+
+	want := `3 resources created by specs were not released:
+  (outside of specs)
+    - chart directory /work/adapter-1: cleaned up by the suite
+  [Suite: cluster] creates
+    - cluster c2: cleaned up by the suite
+    - nodepool np1 (cluster c2): final cleanup failed: boom
+`
+	if got := FormatLeaks(leaks); got != want {
+		t.Errorf("FormatLeaks() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAdapterSubscriptionID(t *testing.T) {
+	tests := []struct {
+		name   string
+		values string
+		want   string
+	}{
+		{
+			name:   "test adapter",
+			values: "broker:\n  googlepubsub:\n    subscriptionId: hyperfleet-clusters-cl-m-wrong-ds\n    createTopicIfMissing: true\n",
+			want:   "hyperfleet-clusters-cl-m-wrong-ds",
+		},
+		{name: "placeholder", values: "broker:\n  googlepubsub:\n    subscriptionId: CHANGE_ME\n"},
+		{name: "no broker", values: "image:\n  tag: latest\n"},
+		{name: "invalid YAML", values: "broker: ["},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adapterSubscriptionID([]byte(tt.values)); got != tt.want {
+				t.Errorf("adapterSubscriptionID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package helper
+
+import (
+	"errors"
+
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+)
+
+// BeAPIErrorWithStatus succeeds when the error is, or wraps, a *client.APIError with the status code:
+//
+//	_, err := h.Client.CreateCluster(ctx, req)
+//	Expect(err).To(helper.BeAPIErrorWithStatus(http.StatusBadRequest))
+func BeAPIErrorWithStatus(statusCode int) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(err error) (bool, error) {
+		apiErr, ok := asAPIError(err)
+		return ok && apiErr.StatusCode == statusCode, nil
+	}).WithTemplate("Expected\n{{.FormattedActual}}\n{{.To}} be an API error with status code {{.Data}}", statusCode)
+}
+
+// HaveAPIErrorCode succeeds when the error is, or wraps, a *client.APIError with the error code
+func HaveAPIErrorCode(code string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(err error) (bool, error) {
+		apiErr, ok := asAPIError(err)
+		return ok && apiErr.Code == code, nil
+	}).WithTemplate("Expected\n{{.FormattedActual}}\n{{.To}} be an API error with code {{.Data}}", code)
+}
+
+// HaveFieldViolation succeeds when the error is, or wraps, a *client.APIError rejecting the request field:
+//
+//	Expect(err).To(helper.HaveFieldViolation("name"))
+func HaveFieldViolation(field string) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(err error) (bool, error) {
+		apiErr, ok := asAPIError(err)
+		return ok && apiErr.FieldViolation(field) != nil, nil
+	}).WithTemplate("Expected\n{{.FormattedActual}}\n{{.To}} be an API error with a violation of field {{.Data}}", field)
+}
+
+// asAPIError returns the *client.APIError in the chain of err
+func asAPIError(err error) (*client.APIError, bool) {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
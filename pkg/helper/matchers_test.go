@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+)
+
+func TestAPIErrorMatchers(t *testing.T) {
+	apiErr := fmt.Errorf("failed to create cluster: %w", &client.APIError{
+		StatusCode:      http.StatusBadRequest,
+		Action:          "create cluster",
+		Code:            "HYPERFLEET-VAL-001",
+		FieldViolations: []client.FieldViolation{{Field: "name", Message: "must be at most 63 characters"}},
+	})
+
+	tests := []struct {
+		name    string
+		matcher types.GomegaMatcher
+		actual  error
+		want    bool
+	}{
+		{name: "status", matcher: BeAPIErrorWithStatus(http.StatusBadRequest), actual: apiErr, want: true},
+		{name: "other status", matcher: BeAPIErrorWithStatus(http.StatusConflict), actual: apiErr},
+		{name: "code", matcher: HaveAPIErrorCode("HYPERFLEET-VAL-001"), actual: apiErr, want: true},
+		{name: "other code", matcher: HaveAPIErrorCode("HYPERFLEET-CNF-001"), actual: apiErr},
+		{name: "field violation", matcher: HaveFieldViolation("name"), actual: apiErr, want: true},
+		{name: "other field", matcher: HaveFieldViolation("spec"), actual: apiErr},
+		{name: "not an API error", matcher: BeAPIErrorWithStatus(http.StatusBadRequest), actual: errors.New("connection refused")},
+		{name: "no error", matcher: HaveFieldViolation("name"), actual: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.matcher.Match(tt.actual)
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Match(%v) = %t, want %t", tt.actual, got, tt.want)
+			}
+		})
+	}
+
+	msg := BeAPIErrorWithStatus(http.StatusConflict).FailureMessage(apiErr)
+	if !strings.Contains(msg, "unexpected status code 400") || !strings.Contains(msg, "with status code 409") {
+		t.Errorf("FailureMessage() = %q, want the actual error and the expected status", msg)
+	}
+}
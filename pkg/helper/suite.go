@@ -2,8 +2,12 @@ package helper
 
 import (
 	"log"
+	"net/http"
 	"sync"
 
+	"github.com/onsi/ginkgo/v2"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/api/openapi"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
 	k8sclient "github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client/kubernetes"
 	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
@@ -12,7 +16,11 @@ import (
 var (
 	// suiteConfig is loaded once in cmd layer before tests start
 	suiteConfig *config.Config
-	configMutex sync.RWMutex
+	// suiteRunID identifies the run in the labels of the resources created by helpers
+	suiteRunID string
+	// suiteHTTPRecorder records the HTTP interactions of helpers created while it is set
+	suiteHTTPRecorder *client.Recorder
+	configMutex       sync.RWMutex
 )
 
 // SetSuiteConfig sets the global suite configuration for the test suite
@@ -36,6 +44,35 @@ func ClearSuiteConfig() {
 	suiteConfig = nil
 }
 
+// SetRunID sets the run ID helpers label created resources with
+func SetRunID(runID string) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	suiteRunID = runID
+}
+
+// RunID returns the run ID helpers label created resources with
+func RunID() string {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return suiteRunID
+}
+
+// SetHTTPRecorder sets the recorder the HyperFleet and Maestro clients of new helpers send requests through,
+// nil disables recording
+func SetHTTPRecorder(recorder *client.Recorder) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	suiteHTTPRecorder = recorder
+}
+
+// HTTPRecorder returns the recorder set with SetHTTPRecorder, nil when recording is disabled
+func HTTPRecorder() *client.Recorder {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return suiteHTTPRecorder
+}
+
 // New creates a helper instance for testing
 // Creates a new helper per test
 func New() *Helper {
@@ -51,9 +88,43 @@ func New() *Helper {
 	return h
 }
 
+// newHTTPClients returns the client for token requests and the client for API and Maestro requests, which share
+// a transport. Token requests carry credentials and are left out of recordings. The recorder sits below the retries,
+// so every attempt of a retried request is recorded, including the transient errors.
+func newHTTPClients(cfg *config.Config, recorder *client.Recorder) (*http.Client, *http.Client, error) {
+	transport, err := client.NewTransport(cfg.TLS)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpClient := &http.Client{Transport: client.NewRetryTransport(transport, cfg.Retry), Timeout: client.DefaultTimeout}
+	if recorder == nil {
+		return httpClient, httpClient, nil
+	}
+	apiHTTPClient := &http.Client{
+		Transport: client.NewRetryTransport(recorder.Transport(transport), cfg.Retry),
+		Timeout:   client.DefaultTimeout,
+	}
+	return httpClient, apiHTTPClient, nil
+}
+
 // newHelper creates a new Helper instance (internal use)
 func newHelper(cfg *config.Config) (*Helper, error) {
-	cl, err := client.NewHyperFleetClient(cfg.API.URL, nil)
+	httpClient, apiHTTPClient, err := newHTTPClients(cfg, HTTPRecorder())
+	if err != nil {
+		return nil, err
+	}
+	// One token source authenticates both the HyperFleet and the Maestro client, sharing cached tokens
+	tokenSource, err := client.NewTokenSource(cfg.Auth, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	var auth openapi.RequestEditorFn
+	var opts []openapi.ClientOption
+	if tokenSource != nil {
+		auth = client.BearerAuth(tokenSource)
+		opts = append(opts, openapi.WithRequestEditorFn(auth))
+	}
+	cl, err := client.NewHyperFleetClient(cfg.API.URL, apiHTTPClient, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,10 +134,24 @@ func newHelper(cfg *config.Config) (*Helper, error) {
 		return nil, err
 	}
 
+	// Label created resources with the run and spec, and record them for the AfterSuite leak check
+	cl.RunID = RunID()
+	cl.SpecName = func() string {
+		return ginkgo.CurrentSpecReport().FullText()
+	}
+	cl.OnClusterCreated = func(clusterID string) {
+		recordResource(LedgerKindCluster, clusterID, "")
+	}
+	cl.OnNodePoolCreated = func(clusterID, nodepoolID string) {
+		recordResource(LedgerKindNodePool, nodepoolID, clusterID)
+	}
+
 	return &Helper{
-		Cfg:       cfg,
-		Client:    cl,
-		K8sClient: k8sClient,
+		Cfg:        cfg,
+		Client:     cl,
+		K8sClient:  k8sClient,
+		httpClient: apiHTTPClient,
+		auth:       auth,
 		// MaestroClient is initialized lazily via GetMaestroClient() to avoid
 		// unnecessary K8s API calls in test suites that don't use Maestro
 	}, nil
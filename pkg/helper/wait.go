@@ -15,7 +15,7 @@ import (
 func (h *Helper) WaitForClusterCondition(ctx context.Context, clusterID string, conditionType string, expectedStatus openapi.ResourceConditionStatus, timeout time.Duration) error {
 	logger.Debug("waiting for cluster condition", "cluster_id", clusterID, "condition_type", conditionType, "expected_status", expectedStatus, "timeout", timeout)
 
-	Eventually(func(g Gomega) {
+	Eventually(ctx, func(g Gomega) {
 		cluster, err := h.Client.GetCluster(ctx, clusterID)
 		g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster")
 		g.Expect(cluster).NotTo(BeNil(), "cluster is nil")
@@ -39,7 +39,7 @@ func (h *Helper) WaitForClusterCondition(ctx context.Context, clusterID string,
 
 // WaitForAdapterCondition waits for a specific adapter condition to be in the expected status
 func (h *Helper) WaitForAdapterCondition(ctx context.Context, clusterID, adapterName, condType string, expectedStatus openapi.AdapterConditionStatus, timeout time.Duration) error {
-	Eventually(func(g Gomega) {
+	Eventually(ctx, func(g Gomega) {
 		statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 		g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 
@@ -62,7 +62,7 @@ func (h *Helper) WaitForAdapterCondition(ctx context.Context, clusterID, adapter
 
 // WaitForAllAdapterConditions waits for all adapters to have the specified condition
 func (h *Helper) WaitForAllAdapterConditions(ctx context.Context, clusterID, condType string, expectedStatus openapi.AdapterConditionStatus, timeout time.Duration) error {
-	Eventually(func(g Gomega) {
+	Eventually(ctx, func(g Gomega) {
 		statuses, err := h.Client.GetClusterStatuses(ctx, clusterID)
 		g.Expect(err).NotTo(HaveOccurred(), "failed to get cluster statuses")
 
@@ -81,7 +81,7 @@ func (h *Helper) WaitForAllAdapterConditions(ctx context.Context, clusterID, con
 func (h *Helper) WaitForNodePoolCondition(ctx context.Context, clusterID, nodepoolID string, conditionType string, expectedStatus openapi.ResourceConditionStatus, timeout time.Duration) error {
 	logger.Debug("waiting for nodepool condition", "cluster_id", clusterID, "nodepool_id", nodepoolID, "condition_type", conditionType, "expected_status", expectedStatus, "timeout", timeout)
 
-	Eventually(func(g Gomega) {
+	Eventually(ctx, func(g Gomega) {
 		nodepool, err := h.Client.GetNodePool(ctx, clusterID, nodepoolID)
 		g.Expect(err).NotTo(HaveOccurred(), "failed to get nodepool")
 		g.Expect(nodepool).NotTo(BeNil(), "nodepool is nil")
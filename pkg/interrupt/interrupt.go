@@ -0,0 +1,98 @@
+// Package interrupt turns SIGINT and SIGTERM into context cancellation, so a run can clean up before it exits
+package interrupt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ExitCode is the exit code of a process forced to exit by a second signal or an expired grace period
+const ExitCode = 130
+
+var (
+	mu          sync.Mutex
+	gracePeriod time.Duration
+	hooks       []func()
+
+	// exit is replaced in tests
+	exit = os.Exit
+)
+
+// SetGracePeriod sets how long the process may clean up after the first signal before it is forced to exit
+// Zero waits for the cleanup to finish.
+func SetGracePeriod(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	gracePeriod = d
+}
+
+// OnForceExit registers a function run before the process is forced to exit, e.g. to report what was cleaned up
+func OnForceExit(hook func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// NotifyContext returns a context cancelled by the first SIGINT or SIGTERM, with the signal as cause.
+// A second signal, or the grace period expiring after the first, runs the force-exit hooks and exits
+// the process with ExitCode. The stop function stops listening for signals and cancels the context.
+func NotifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-signals:
+			cancel(fmt.Errorf("received %s", sig))
+		case <-stopped:
+			return
+		}
+
+		mu.Lock()
+		grace := gracePeriod
+		mu.Unlock()
+		var deadline <-chan time.Time
+		if grace > 0 {
+			deadline = time.After(grace)
+			log.Printf("Interrupted, cleaning up for up to %s; interrupt again to exit immediately", grace)
+		} else {
+			log.Printf("Interrupted, cleaning up; interrupt again to exit immediately")
+		}
+
+		select {
+		case sig := <-signals:
+			forceExit(fmt.Sprintf("received %s again", sig))
+		case <-deadline:
+			forceExit(fmt.Sprintf("cleanup grace period of %s expired", grace))
+		case <-stopped:
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(stopped)
+			cancel(context.Canceled)
+		})
+	}
+}
+
+func forceExit(reason string) {
+	log.Printf("Exiting without completing cleanup: %s", reason)
+	mu.Lock()
+	registered := append([]func(){}, hooks...)
+	mu.Unlock()
+	for _, hook := range registered {
+		hook()
+	}
+	exit(ExitCode)
+}
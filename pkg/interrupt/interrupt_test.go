@@ -0,0 +1,82 @@
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyContext(t *testing.T) {
+	exited := make(chan int, 1)
+	exit = func(code int) { exited <- code }
+	t.Cleanup(func() { exit = os.Exit })
+
+	hookRan := false
+	OnForceExit(func() { hookRan = true })
+	SetGracePeriod(0)
+
+	ctx, stop := NotifyContext(context.Background())
+	defer stop()
+
+	// The first signal cancels the context
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not cancelled by the first signal")
+	}
+	if cause := context.Cause(ctx); cause == nil || cause.Error() != "received terminated" {
+		t.Errorf("context.Cause() = %v, want the signal", cause)
+	}
+
+	// The second one forces the exit
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case code := <-exited:
+		if code != ExitCode || !hookRan {
+			t.Errorf("exit code = %d, hook ran = %t; want %d after running the hook", code, hookRan, ExitCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second signal did not force the exit")
+	}
+}
+
+func TestNotifyContextGracePeriod(t *testing.T) {
+	exited := make(chan int, 1)
+	exit = func(code int) { exited <- code }
+	t.Cleanup(func() { exit = os.Exit })
+	SetGracePeriod(50 * time.Millisecond)
+	t.Cleanup(func() { SetGracePeriod(0) })
+
+	ctx, stop := NotifyContext(context.Background())
+	defer stop()
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	<-ctx.Done()
+
+	select {
+	case code := <-exited:
+		if code != ExitCode {
+			t.Errorf("exit code = %d, want %d", code, ExitCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expired grace period did not force the exit")
+	}
+}
+
+func TestNotifyContextStop(t *testing.T) {
+	ctx, stop := NotifyContext(context.Background())
+	stop()
+	stop()
+	if !errors.Is(context.Cause(ctx), context.Canceled) {
+		t.Errorf("context.Cause() after stop = %v, want context.Canceled", context.Cause(ctx))
+	}
+}
@@ -8,11 +8,12 @@ const (
 )
 
 // Stability labels - Test quality dimension: determines CI gate policy
-// const (
-// 	Stable    = "stable"    // Production-ready: stable and reliable, must pass to merge (Blocking)
-// 	Informing = "informing" // Observation period: new test onboarding (Non-blocking)
-// 	Flaky     = "flaky"     // Known unstable: quarantined for investigation
-// )
+// Specs without a stability label are treated as Stable
+const (
+	Stable    = "stable"    // Production-ready: stable and reliable, must pass to merge (Blocking)
+	Informing = "informing" // Observation period: new test onboarding (Non-blocking)
+	Flaky     = "flaky"     // Known unstable: quarantined for investigation (Non-blocking)
+)
 
 // Scenario labels - Test path dimension: describes test design intent
 const (
@@ -28,6 +29,10 @@ const (
 
 // Constraint labels - Execution constraint dimension: determines scheduling strategy
 const (
-	Disruptive = "disruptive" // Destructive testing: fault injection
-	Slow       = "slow"       // Long-running: execution time exceeds 5-10 minutes
+	Disruptive        = "disruptive"         // Destructive testing: fault injection
+	Slow              = "slow"               // Long-running: execution time exceeds 5-10 minutes
+	AdapterDeployment = "adapter-deployment" // Deploys dedicated adapters via Helm: requires adapterDeployment config
 )
+
+// Serial is the label Ginkgo adds to specs with the ginkgo.Serial decorator, it is not applied with ginkgo.Label
+const Serial = "Serial"
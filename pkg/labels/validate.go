@@ -1,16 +1,25 @@
 package labels
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ValidateLabels verifies that tests contain all required label dimensions
+// and only labels defined in this package
 func ValidateLabels(testLabels []string) error {
 	hasSeverity := false
+	var stability, unknown []string
 
 	for _, label := range testLabels {
 		switch label {
 		// Severity dimension (required)
 		case Tier0, Tier1, Tier2:
 			hasSeverity = true
+		// Stability dimension (optional, at most one)
+		case Stable, Informing, Flaky:
+			stability = append(stability, label)
 		// Scenario dimension (optional)
 		case Negative, Performance:
 			// Optional, no validation needed
@@ -18,14 +27,49 @@ func ValidateLabels(testLabels []string) error {
 		case Upgrade:
 			// Optional, no validation needed
 		// Constraint dimension (optional)
-		case Disruptive, Slow:
+		case Disruptive, Slow, AdapterDeployment:
 			// Optional, no validation needed
+		// Added by Ginkgo for the Serial decorator
+		case Serial:
+			// Optional, no validation needed
+		default:
+			unknown = append(unknown, label)
 		}
 	}
 
+	var problems []string
 	if !hasSeverity {
-		return fmt.Errorf("missing severity label (%s/%s/%s)", Tier0, Tier1, Tier2)
+		problems = append(problems, fmt.Sprintf("missing severity label (%s/%s/%s)", Tier0, Tier1, Tier2))
+	}
+	if len(stability) > 1 {
+		problems = append(problems, fmt.Sprintf("conflicting stability labels %s", strings.Join(stability, ", ")))
+	}
+	if len(unknown) > 0 {
+		problems = append(problems, fmt.Sprintf("unknown labels %s (not defined in pkg/labels)", strings.Join(unknown, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// ValidateSerial verifies that specs whose labels mean they affect other specs are marked ginkgo.Serial,
+// so parallel runs never execute them alongside other specs
+func ValidateSerial(testLabels []string, serial bool) error {
+	if serial {
+		return nil
 	}
 
+	var requireSerial []string
+	for _, label := range testLabels {
+		if label == Disruptive || label == AdapterDeployment {
+			requireSerial = append(requireSerial, label)
+		}
+	}
+	if len(requireSerial) > 0 {
+		return fmt.Errorf("labels %s require the ginkgo.Serial decorator", strings.Join(requireSerial, ", "))
+	}
 	return nil
 }
@@ -47,6 +47,111 @@ func TestAllE2ETestsHaveRequiredLabels(t *testing.T) {
 	}
 }
 
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		wantErr []string
+	}{
+		{
+			name:   "severity only",
+			labels: []string{labels.Tier0},
+		},
+		{
+			name:   "severity with optional dimensions",
+			labels: []string{labels.Tier1, labels.Negative, labels.Slow, labels.AdapterDeployment},
+		},
+		{
+			name:   "severity with stability",
+			labels: []string{labels.Tier1, labels.Informing},
+		},
+		{
+			name:    "conflicting stability labels",
+			labels:  []string{labels.Tier1, labels.Stable, labels.Flaky},
+			wantErr: []string{"conflicting stability labels stable, flaky"},
+		},
+		{
+			name:    "missing severity",
+			labels:  []string{labels.Negative},
+			wantErr: []string{"missing severity label"},
+		},
+		{
+			name:    "unknown label",
+			labels:  []string{labels.Tier2, "tier-0"},
+			wantErr: []string{"unknown labels tier-0"},
+		},
+		{
+			name:    "missing severity and unknown label",
+			labels:  []string{"critical"},
+			wantErr: []string{"missing severity label", "unknown labels critical"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := labels.ValidateLabels(tt.labels)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateLabels(%v) unexpected error: %v", tt.labels, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateLabels(%v) succeeded, want error", tt.labels)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("ValidateLabels(%v) error %q does not contain %q", tt.labels, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSerial(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		serial  bool
+		wantErr string
+	}{
+		{
+			name:   "regular spec in parallel",
+			labels: []string{labels.Tier0},
+		},
+		{
+			name:   "adapter deployment marked serial",
+			labels: []string{labels.Tier1, labels.AdapterDeployment},
+			serial: true,
+		},
+		{
+			name:    "adapter deployment not serial",
+			labels:  []string{labels.Tier1, labels.AdapterDeployment},
+			wantErr: "labels adapter-deployment require the ginkgo.Serial decorator",
+		},
+		{
+			name:    "disruptive not serial",
+			labels:  []string{labels.Tier1, labels.Disruptive, labels.AdapterDeployment},
+			wantErr: "labels disruptive, adapter-deployment require the ginkgo.Serial decorator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := labels.ValidateSerial(tt.labels, tt.serial)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateSerial(%v, %t) unexpected error: %v", tt.labels, tt.serial, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ValidateSerial(%v, %t) error = %v, want %q", tt.labels, tt.serial, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 // testSpec represents a Ginkgo test specification with its labels
 type testSpec struct {
 	Name   string   // Test name from ginkgo.Describe
@@ -211,14 +316,19 @@ func constantToLabelValue(constName string) string {
 		"Tier0": labels.Tier0,
 		"Tier1": labels.Tier1,
 		"Tier2": labels.Tier2,
+		// Stability
+		"Stable":    labels.Stable,
+		"Informing": labels.Informing,
+		"Flaky":     labels.Flaky,
 		// Scenario
 		"Negative":    labels.Negative,
 		"Performance": labels.Performance,
 		// Functionality
 		"Upgrade": labels.Upgrade,
 		// Constraint
-		"Disruptive": labels.Disruptive,
-		"Slow":       labels.Slow,
+		"Disruptive":        labels.Disruptive,
+		"Slow":              labels.Slow,
+		"AdapterDeployment": labels.AdapterDeployment,
 	}
 
 	return mapping[constName]
@@ -0,0 +1,337 @@
+package parallel
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// ProtocolEnvVar selects the protocol Ginkgo parallel processes use to reach the server
+// The server speaks HTTP, so workers must be started with ProtocolEnvVar=ProtocolHTTP
+const (
+	ProtocolEnvVar = "GINKGO_PARALLEL_PROTOCOL"
+	ProtocolHTTP   = "HTTP"
+)
+
+// beforeSuiteState is the SynchronizedBeforeSuite result process 1 hands to the other processes
+type beforeSuiteState struct {
+	Data  []byte
+	State types.SpecState
+}
+
+// indexCounter is the payload of the counter endpoint that hands out specs to processes
+type indexCounter struct {
+	Index int
+}
+
+// Server coordinates Ginkgo parallel processes the way the ginkgo CLI does for `ginkgo -p`.
+// Ginkgo keeps its server in an internal package; this is a port of its HTTP server and
+// must follow the protocol of the Ginkgo version in go.mod.
+type Server struct {
+	listener      net.Listener
+	reporter      reporters.Reporter
+	output        io.Writer
+	parallelTotal int
+
+	lock                   sync.Mutex
+	alive                  []func() bool
+	beforeSuiteState       beforeSuiteState
+	reportBeforeSuiteState types.SpecState
+	counter                int
+	shouldAbort            bool
+	numSuiteDidBegins      int
+	numSuiteDidEnds        int
+	aggregatedReport       types.Report
+	reportHoldingArea      []types.SpecReport
+	done                   chan struct{}
+}
+
+// NewServer listens on a free local port for parallelTotal processes, forwarding their results to reporter
+func NewServer(parallelTotal int, reporter reporters.Reporter) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		listener:         listener,
+		reporter:         reporter,
+		output:           os.Stdout,
+		parallelTotal:    parallelTotal,
+		alive:            make([]func() bool, parallelTotal),
+		beforeSuiteState: beforeSuiteState{State: types.SpecStateInvalid},
+		done:             make(chan struct{}),
+	}, nil
+}
+
+// Start serves the protocol in the background
+func (s *Server) Start() {
+	mux := http.NewServeMux()
+
+	// Streaming endpoints
+	mux.HandleFunc("/suite-will-begin", s.suiteWillBegin)
+	mux.HandleFunc("/did-run", s.didRun)
+	mux.HandleFunc("/suite-did-end", s.suiteDidEnd)
+	mux.HandleFunc("/emit-output", s.emitOutput)
+	mux.HandleFunc("/progress-report", s.progressReport)
+
+	// Synchronization endpoints
+	mux.HandleFunc("/report-before-suite-completed", s.reportBeforeSuiteCompleted)
+	mux.HandleFunc("/report-before-suite-state", s.getReportBeforeSuiteState)
+	mux.HandleFunc("/before-suite-completed", s.beforeSuiteCompleted)
+	mux.HandleFunc("/before-suite-state", s.getBeforeSuiteState)
+	mux.HandleFunc("/have-nonprimary-procs-finished", s.haveNonprimaryProcsFinished)
+	mux.HandleFunc("/aggregated-nonprimary-procs-report", s.aggregatedNonprimaryProcsReport)
+	mux.HandleFunc("/counter", s.nextCounter)
+	mux.HandleFunc("/up", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc("/abort", s.abort)
+
+	server := &http.Server{Handler: mux} // #nosec G112 -- loopback only, clients are our own worker processes
+	go func() { _ = server.Serve(s.listener) }()
+}
+
+// Close stops listening
+func (s *Server) Close() {
+	_ = s.listener.Close()
+}
+
+// Address is the ParallelHost passed to the worker processes
+func (s *Server) Address() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// RegisterAlive registers the liveness check of a one-based process
+func (s *Server) RegisterAlive(proc int, alive func() bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.alive[proc-1] = alive
+}
+
+// Done is closed once every process has reported the end of the suite
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Server) procIsAlive(proc int) bool {
+	s.lock.Lock()
+	alive := s.alive[proc-1]
+	s.lock.Unlock()
+	return alive == nil || alive()
+}
+
+func (s *Server) nonprimaryProcsFinished() bool {
+	for proc := 2; proc <= s.parallelTotal; proc++ {
+		if s.procIsAlive(proc) {
+			return false
+		}
+	}
+	return true
+}
+
+// decode reads a JSON request body, answering 400 if it is malformed
+func decode(w http.ResponseWriter, r *http.Request, object any) bool {
+	defer func() { _ = r.Body.Close() }()
+	if json.NewDecoder(r.Body).Decode(object) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// respond writes a JSON payload
+func respond(w http.ResponseWriter, object any) {
+	_ = json.NewEncoder(w).Encode(object)
+}
+
+// pending answers polls for state that is not available yet: 425 while the producing process runs, 410 once it is gone
+func pending(w http.ResponseWriter, producerAlive bool) {
+	if producerAlive {
+		w.WriteHeader(http.StatusTooEarly)
+		return
+	}
+	w.WriteHeader(http.StatusGone)
+}
+
+func (s *Server) suiteWillBegin(w http.ResponseWriter, r *http.Request) {
+	var report types.Report
+	if !decode(w, r, &report) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.numSuiteDidBegins++
+
+	// All processes send the same summary, emit it once every process has started
+	if s.numSuiteDidBegins == s.parallelTotal {
+		s.reporter.SuiteWillBegin(report)
+		for _, spec := range s.reportHoldingArea {
+			s.reporter.WillRun(spec)
+			s.reporter.DidRun(spec)
+		}
+		s.reportHoldingArea = nil
+	}
+}
+
+func (s *Server) didRun(w http.ResponseWriter, r *http.Request) {
+	var spec types.SpecReport
+	if !decode(w, r, &spec) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.numSuiteDidBegins == s.parallelTotal {
+		s.reporter.WillRun(spec)
+		s.reporter.DidRun(spec)
+		return
+	}
+	s.reportHoldingArea = append(s.reportHoldingArea, spec)
+}
+
+func (s *Server) suiteDidEnd(w http.ResponseWriter, r *http.Request) {
+	var report types.Report
+	if !decode(w, r, &report) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.numSuiteDidEnds++
+	if s.numSuiteDidEnds == 1 {
+		s.aggregatedReport = report
+	} else {
+		s.aggregatedReport = s.aggregatedReport.Add(report)
+	}
+
+	if s.numSuiteDidEnds == s.parallelTotal {
+		s.reporter.SuiteDidEnd(s.aggregatedReport)
+		close(s.done)
+	}
+}
+
+func (s *Server) emitOutput(w http.ResponseWriter, r *http.Request) {
+	output, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if _, err := s.output.Write(output); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) progressReport(w http.ResponseWriter, r *http.Request) {
+	var report types.ProgressReport
+	if !decode(w, r, &report) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reporter.EmitProgressReport(report)
+}
+
+func (s *Server) reportBeforeSuiteCompleted(w http.ResponseWriter, r *http.Request) {
+	var state types.SpecState
+	if !decode(w, r, &state) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reportBeforeSuiteState = state
+}
+
+func (s *Server) getReportBeforeSuiteState(w http.ResponseWriter, _ *http.Request) {
+	proc1Alive := s.procIsAlive(1)
+
+	s.lock.Lock()
+	state := s.reportBeforeSuiteState
+	s.lock.Unlock()
+
+	if state == types.SpecStateInvalid {
+		pending(w, proc1Alive)
+		return
+	}
+	respond(w, state)
+}
+
+func (s *Server) beforeSuiteCompleted(w http.ResponseWriter, r *http.Request) {
+	var state beforeSuiteState
+	if !decode(w, r, &state) {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.beforeSuiteState = state
+}
+
+func (s *Server) getBeforeSuiteState(w http.ResponseWriter, _ *http.Request) {
+	proc1Alive := s.procIsAlive(1)
+
+	s.lock.Lock()
+	state := s.beforeSuiteState
+	s.lock.Unlock()
+
+	if state.State == types.SpecStateInvalid {
+		pending(w, proc1Alive)
+		return
+	}
+	respond(w, state)
+}
+
+func (s *Server) haveNonprimaryProcsFinished(w http.ResponseWriter, _ *http.Request) {
+	if !s.nonprimaryProcsFinished() {
+		w.WriteHeader(http.StatusTooEarly)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) aggregatedNonprimaryProcsReport(w http.ResponseWriter, _ *http.Request) {
+	if !s.nonprimaryProcsFinished() {
+		w.WriteHeader(http.StatusTooEarly)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// A process that exited without reporting leaves the aggregated report incomplete
+	if s.numSuiteDidEnds != s.parallelTotal-1 {
+		w.WriteHeader(http.StatusGone)
+		return
+	}
+	respond(w, s.aggregatedReport)
+}
+
+func (s *Server) nextCounter(w http.ResponseWriter, _ *http.Request) {
+	s.lock.Lock()
+	counter := s.counter
+	s.counter++
+	s.lock.Unlock()
+
+	respond(w, indexCounter{Index: counter})
+}
+
+func (s *Server) abort(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if r.Method == http.MethodGet {
+		if s.shouldAbort {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	s.shouldAbort = true
+}
@@ -0,0 +1,148 @@
+package parallel
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// recordingReporter counts the specs and suite ends forwarded by the server
+type recordingReporter struct {
+	reporters.NoopReporter
+	specs     []types.SpecReport
+	suiteEnds []types.Report
+}
+
+func (r *recordingReporter) DidRun(spec types.SpecReport) {
+	r.specs = append(r.specs, spec)
+}
+
+func (r *recordingReporter) SuiteDidEnd(report types.Report) {
+	r.suiteEnds = append(r.suiteEnds, report)
+}
+
+func startServer(t *testing.T, total int, reporter reporters.Reporter) *Server {
+	t.Helper()
+	server, err := NewServer(total, reporter)
+	if err != nil {
+		t.Fatalf("NewServer() error: %v", err)
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(t *testing.T, server *Server, path string, object any) {
+	t.Helper()
+	body, err := json.Marshal(object)
+	if err != nil {
+		t.Fatalf("marshal %s payload: %v", path, err)
+	}
+	resp, err := http.Post(server.Address()+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST %s status = %d, want 200", path, resp.StatusCode)
+	}
+}
+
+func get(t *testing.T, server *Server, path string, object any) int {
+	t.Helper()
+	resp, err := http.Get(server.Address() + path)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusOK && object != nil {
+		if err := json.NewDecoder(resp.Body).Decode(object); err != nil {
+			t.Fatalf("decode %s response: %v", path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServerCounter(t *testing.T) {
+	server := startServer(t, 2, reporters.NoopReporter{})
+
+	for want := 0; want < 3; want++ {
+		var counter indexCounter
+		if status := get(t, server, "/counter", &counter); status != http.StatusOK || counter.Index != want {
+			t.Fatalf("counter = %d (status %d), want %d", counter.Index, status, want)
+		}
+	}
+}
+
+func TestServerBeforeSuiteState(t *testing.T) {
+	server := startServer(t, 2, reporters.NoopReporter{})
+	proc1Alive := &atomic.Bool{}
+	proc1Alive.Store(true)
+	server.RegisterAlive(1, proc1Alive.Load)
+
+	if status := get(t, server, "/before-suite-state", nil); status != http.StatusTooEarly {
+		t.Errorf("state before process 1 completed: status = %d, want 425", status)
+	}
+
+	post(t, server, "/before-suite-completed", beforeSuiteState{Data: []byte("shared"), State: types.SpecStatePassed})
+	var state beforeSuiteState
+	if status := get(t, server, "/before-suite-state", &state); status != http.StatusOK || string(state.Data) != "shared" {
+		t.Errorf("state after process 1 completed = %q (status %d), want \"shared\"", state.Data, status)
+	}
+}
+
+func TestServerBeforeSuiteStateProcessGone(t *testing.T) {
+	server := startServer(t, 2, reporters.NoopReporter{})
+	server.RegisterAlive(1, func() bool { return false })
+
+	if status := get(t, server, "/before-suite-state", nil); status != http.StatusGone {
+		t.Errorf("state after process 1 exited: status = %d, want 410", status)
+	}
+}
+
+func TestServerAggregatesReports(t *testing.T) {
+	reporter := &recordingReporter{}
+	server := startServer(t, 2, reporter)
+	proc2Alive := &atomic.Bool{}
+	proc2Alive.Store(true)
+	server.RegisterAlive(1, func() bool { return true })
+	server.RegisterAlive(2, proc2Alive.Load)
+
+	post(t, server, "/suite-will-begin", types.Report{})
+	// Specs are held until every process has begun
+	post(t, server, "/did-run", types.SpecReport{LeafNodeText: "early"})
+	if len(reporter.specs) != 0 {
+		t.Fatalf("spec forwarded before all processes began")
+	}
+	post(t, server, "/suite-will-begin", types.Report{})
+	if len(reporter.specs) != 1 {
+		t.Fatalf("held spec not forwarded once all processes began")
+	}
+
+	post(t, server, "/suite-did-end", types.Report{SuiteSucceeded: true, SpecReports: types.SpecReports{{LeafNodeText: "on proc 2"}}})
+	if status := get(t, server, "/aggregated-nonprimary-procs-report", nil); status != http.StatusTooEarly {
+		t.Errorf("aggregated report while process 2 runs: status = %d, want 425", status)
+	}
+
+	proc2Alive.Store(false)
+	var aggregated types.Report
+	if status := get(t, server, "/aggregated-nonprimary-procs-report", &aggregated); status != http.StatusOK || len(aggregated.SpecReports) != 1 {
+		t.Fatalf("aggregated report = %d specs (status %d), want 1", len(aggregated.SpecReports), status)
+	}
+
+	select {
+	case <-server.Done():
+		t.Fatal("Done closed before process 1 reported")
+	default:
+	}
+	post(t, server, "/suite-did-end", types.Report{SuiteSucceeded: true, SpecReports: types.SpecReports{{LeafNodeText: "on proc 1"}}})
+	<-server.Done()
+	if len(reporter.suiteEnds) != 1 || len(reporter.suiteEnds[0].SpecReports) != 2 {
+		t.Errorf("reporter received %d suite ends, want 1 with both processes' specs", len(reporter.suiteEnds))
+	}
+}
@@ -0,0 +1,253 @@
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client"
+	k8sclient "github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client/kubernetes"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/client/maestro"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/helper"
+)
+
+// Check statuses
+const (
+	StatusPass = "PASS"
+	StatusFail = "FAIL"
+	StatusSkip = "SKIP"
+)
+
+// checkTimeout bounds each check that talks to a remote service
+const checkTimeout = 15 * time.Second
+
+// RequiredBinaries are the external tools invoked by the test helpers
+var RequiredBinaries = []string{"helm", "kubectl"}
+
+// AdapterDeploymentBinaries are the external tools only specs deploying their own adapters need:
+// git clones the adapter chart, envsubst renders its values and gcloud manages its Pub/Sub subscription
+var AdapterDeploymentBinaries = []string{"envsubst", "gcloud", "git"}
+
+// Result is the outcome of a single preflight check
+type Result struct {
+	Name   string
+	Status string
+	Detail string
+}
+
+// Report holds the results of all preflight checks in execution order
+type Report struct {
+	Results []Result
+}
+
+// Passed reports whether no check failed
+func (r Report) Passed() bool {
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// Print writes the results as a CHECK/STATUS/DETAIL table
+func (r Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK\tSTATUS\tDETAIL")
+	for _, result := range r.Results {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, result.Status, result.Detail)
+	}
+	return tw.Flush()
+}
+
+// newResult builds a passing result with the given detail, or a failing one carrying err
+func newResult(name string, err error, detail string) Result {
+	if err != nil {
+		return Result{Name: name, Status: StatusFail, Detail: err.Error()}
+	}
+	return Result{Name: name, Status: StatusPass, Detail: detail}
+}
+
+// Options controls which checks Run performs
+type Options struct {
+	Config *config.Config
+
+	// CheckAdapterDeployment enables the adapterDeployment completeness check and the AdapterDeploymentBinaries
+	// checks, set when the selected specs deploy their own adapters
+	CheckAdapterDeployment bool
+}
+
+// Run validates the environment the tests depend on and returns one result per check
+func Run(ctx context.Context, opts Options) Report {
+	cfg := opts.Config
+
+	results := checkBinaries(opts.CheckAdapterDeployment)
+	missingBinaries := make(map[string]bool)
+	for _, result := range results {
+		if result.Status == StatusFail {
+			missingBinaries[strings.TrimPrefix(result.Name, "binary/")] = true
+		}
+	}
+
+	results = append(results, checkAPI(ctx, cfg), checkKubernetes(), checkMaestro())
+
+	for _, group := range []struct {
+		resourceType string
+		adapters     []string
+	}{
+		{resourceType: helper.ResourceTypeClusters, adapters: cfg.Adapters.Cluster},
+		{resourceType: helper.ResourceTypeNodepools, adapters: cfg.Adapters.NodePool},
+	} {
+		for _, adapterName := range group.adapters {
+			if missingBinaries["helm"] {
+				results = append(results, Result{
+					Name:   adapterCheckName(group.resourceType, adapterName),
+					Status: StatusSkip,
+					Detail: "helm not found in PATH",
+				})
+				continue
+			}
+			results = append(results, checkAdapterDeployed(ctx, cfg.Namespace, group.resourceType, adapterName))
+		}
+	}
+
+	if opts.CheckAdapterDeployment {
+		results = append(results, checkAdapterDeploymentConfig(cfg))
+	} else {
+		results = append(results, Result{
+			Name:   "adapterDeployment",
+			Status: StatusSkip,
+			Detail: "no selected specs deploy adapters",
+		})
+	}
+
+	return Report{Results: results}
+}
+
+// checkBinaries checks the RequiredBinaries, and the AdapterDeploymentBinaries when adapters are deployed
+func checkBinaries(adapterDeployment bool) []Result {
+	var results []Result
+	for _, name := range RequiredBinaries {
+		results = append(results, checkBinary(name))
+	}
+	for _, name := range AdapterDeploymentBinaries {
+		if !adapterDeployment {
+			results = append(results, Result{
+				Name:   "binary/" + name,
+				Status: StatusSkip,
+				Detail: "no selected specs deploy adapters",
+			})
+			continue
+		}
+		results = append(results, checkBinary(name))
+	}
+	return results
+}
+
+// checkBinary confirms an external tool is on PATH
+func checkBinary(name string) Result {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		err = fmt.Errorf("%s not found in PATH", name)
+	}
+	return newResult("binary/"+name, err, path)
+}
+
+// checkAPI lists clusters to confirm the HyperFleet API is reachable
+func checkAPI(ctx context.Context, cfg *config.Config) Result {
+	cl, err := client.NewHyperFleetClientFromConfig(cfg)
+	if err != nil {
+		return newResult("api", err, "")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if _, err := cl.ListClusters(ctx); err != nil {
+		return newResult("api", fmt.Errorf("%s: %w", cfg.API.URL, err), "")
+	}
+	return newResult("api", nil, cfg.API.URL)
+}
+
+// checkKubernetes confirms the kubeconfig works by asking the API server for its version
+func checkKubernetes() Result {
+	k8sClient, err := k8sclient.NewClient()
+	if err != nil {
+		return newResult("kubernetes", err, "")
+	}
+
+	version, err := k8sClient.Discovery().ServerVersion()
+	if err != nil {
+		return newResult("kubernetes", fmt.Errorf("failed to reach kubernetes API server: %w", err), "")
+	}
+	return newResult("kubernetes", nil, "server "+version.GitVersion)
+}
+
+// checkMaestro resolves the Maestro URL the same way maestro.NewClient does
+func checkMaestro() Result {
+	if url := os.Getenv("MAESTRO_URL"); url != "" {
+		return newResult("maestro", nil, url+" (MAESTRO_URL)")
+	}
+	url, err := maestro.DiscoverMaestroURL()
+	return newResult("maestro", err, url)
+}
+
+// helmRelease is the subset of `helm list -o json` output used by the adapter check
+type helmRelease struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// adapterCheckName names the deployment check of a required adapter
+func adapterCheckName(resourceType, adapterName string) string {
+	return fmt.Sprintf("adapter/%s/%s", resourceType, adapterName)
+}
+
+// checkAdapterDeployed looks for a deployed release carrying the labels set by deploy-scripts for the adapter
+func checkAdapterDeployed(ctx context.Context, namespace, resourceType, adapterName string) Result {
+	name := adapterCheckName(resourceType, adapterName)
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	selector := fmt.Sprintf("adapter-resource-type=%s,adapter-name=%s", resourceType, adapterName)
+	cmd := exec.CommandContext(ctx, "helm", "list", // #nosec G204 -- namespace and selector are from trusted config
+		"-n", namespace,
+		"--all",
+		"--selector", selector,
+		"-o", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return newResult(name, fmt.Errorf("helm list failed: %w", err), "")
+	}
+
+	var releases []helmRelease
+	if err := json.Unmarshal(output, &releases); err != nil {
+		return newResult(name, fmt.Errorf("failed to decode helm list output: %w", err), "")
+	}
+
+	for _, release := range releases {
+		if release.Status == "deployed" {
+			return newResult(name, nil, release.Name)
+		}
+	}
+	if len(releases) > 0 {
+		return newResult(name, fmt.Errorf("release %s is %s", releases[0].Name, releases[0].Status), "")
+	}
+	return newResult(name, fmt.Errorf("no release found in namespace %q", namespace), "")
+}
+
+// checkAdapterDeploymentConfig verifies the settings used by specs that deploy their own adapters
+func checkAdapterDeploymentConfig(cfg *config.Config) Result {
+	if missing := cfg.MissingAdapterDeploymentFields(); len(missing) > 0 {
+		return newResult("adapterDeployment", fmt.Errorf("missing %s", strings.Join(missing, ", ")), "")
+	}
+	return newResult("adapterDeployment", nil, cfg.AdapterDeployment.ChartRepo+"@"+cfg.AdapterDeployment.ChartRef)
+}
@@ -0,0 +1,118 @@
+package preflight
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/config"
+)
+
+func TestReportPassed(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []Result
+		want    bool
+	}{
+		{
+			name:    "no checks",
+			results: nil,
+			want:    true,
+		},
+		{
+			name:    "passed and skipped checks",
+			results: []Result{{Name: "api", Status: StatusPass}, {Name: "adapterDeployment", Status: StatusSkip}},
+			want:    true,
+		},
+		{
+			name:    "one failed check",
+			results: []Result{{Name: "api", Status: StatusPass}, {Name: "kubernetes", Status: StatusFail}},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Report{Results: tt.results}).Passed(); got != tt.want {
+				t.Errorf("Passed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAdapterDeploymentConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.Config
+		wantStatus  string
+		wantMissing []string
+	}{
+		{
+			name: "complete",
+			cfg: config.Config{
+				Namespace: "hyperfleet-e2e",
+				AdapterDeployment: config.AdapterDeploymentConfig{
+					ChartRepo: "https://github.com/openshift-hyperfleet/hyperfleet-adapter.git",
+					ChartRef:  "main",
+					ChartPath: "charts",
+				},
+			},
+			wantStatus: StatusPass,
+		},
+		{
+			name:        "chart ref and path missing",
+			cfg:         config.Config{Namespace: "hyperfleet-e2e", AdapterDeployment: config.AdapterDeploymentConfig{ChartRepo: "repo"}},
+			wantStatus:  StatusFail,
+			wantMissing: []string{"adapterDeployment.chartRef", "adapterDeployment.chartPath"},
+		},
+		{
+			name:        "nothing set",
+			cfg:         config.Config{},
+			wantStatus:  StatusFail,
+			wantMissing: []string{"namespace", "adapterDeployment.chartRepo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkAdapterDeploymentConfig(&tt.cfg)
+			if result.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s (detail: %s)", result.Status, tt.wantStatus, result.Detail)
+			}
+			for _, field := range tt.wantMissing {
+				if !strings.Contains(result.Detail, field) {
+					t.Errorf("detail %q does not mention %s", result.Detail, field)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckBinariesAdapterDeployment(t *testing.T) {
+	// An empty PATH makes every binary missing, so only the skipped checks pass
+	t.Setenv("PATH", "")
+
+	statuses := func(results []Result) map[string]string {
+		out := make(map[string]string)
+		for _, result := range results {
+			out[result.Name] = result.Status
+		}
+		return out
+	}
+
+	got := statuses(checkBinaries(false))
+	for _, name := range RequiredBinaries {
+		if got["binary/"+name] != StatusFail {
+			t.Errorf("binary/%s = %s without adapter deployment, want %s", name, got["binary/"+name], StatusFail)
+		}
+	}
+	for _, name := range AdapterDeploymentBinaries {
+		if got["binary/"+name] != StatusSkip {
+			t.Errorf("binary/%s = %s without adapter deployment, want %s", name, got["binary/"+name], StatusSkip)
+		}
+	}
+
+	got = statuses(checkBinaries(true))
+	if got["binary/gcloud"] != StatusFail {
+		t.Errorf("binary/gcloud = %s with adapter deployment, want %s", got["binary/gcloud"], StatusFail)
+	}
+}
@@ -0,0 +1,156 @@
+package quarantine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Modes controlling how quarantined specs are handled
+const (
+	ModeRun  = "run"  // run quarantined specs but ignore their failures
+	ModeSkip = "skip" // do not run quarantined specs
+)
+
+// DateFormat is the format of expiry dates
+const DateFormat = "2006-01-02"
+
+// Entry quarantines the specs whose full text matches Spec until the end of the Expires date (UTC)
+type Entry struct {
+	Spec    string `json:"spec"`
+	Ticket  string `json:"ticket"`
+	Expires string `json:"expires"`
+
+	pattern *regexp.Regexp
+	expires time.Time
+}
+
+// File is the content of a quarantine file
+type File struct {
+	Quarantine []Entry `json:"quarantine"`
+}
+
+// List holds parsed quarantine entries
+type List struct {
+	Entries []Entry
+}
+
+// ValidateMode checks a quarantine mode
+func ValidateMode(mode string) error {
+	if mode != ModeRun && mode != ModeSkip {
+		return fmt.Errorf("invalid quarantine mode %q (must be %s or %s)", mode, ModeRun, ModeSkip)
+	}
+	return nil
+}
+
+// Load reads and validates a quarantine file, reporting all invalid entries together
+func Load(path string) (*List, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is from trusted config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantine file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates the content of a quarantine file
+func Parse(data []byte) (*List, error) {
+	var file File
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine file: %w", err)
+	}
+
+	var problems []string
+	for i := range file.Quarantine {
+		entry := &file.Quarantine[i]
+		if err := entry.parse(); err != nil {
+			problems = append(problems, fmt.Sprintf("entry %d: %v", i+1, err))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.New("invalid quarantine file:\n  - " + strings.Join(problems, "\n  - "))
+	}
+
+	return &List{Entries: file.Quarantine}, nil
+}
+
+// parse compiles the spec regex and the expiry date of an entry
+func (e *Entry) parse() error {
+	var problems []string
+
+	if e.Spec == "" {
+		problems = append(problems, "spec is required")
+	} else if pattern, err := regexp.Compile(e.Spec); err != nil {
+		problems = append(problems, fmt.Sprintf("spec is not a valid regex: %v", err))
+	} else {
+		e.pattern = pattern
+	}
+
+	if e.Ticket == "" {
+		problems = append(problems, "ticket is required")
+	}
+
+	if e.Expires == "" {
+		problems = append(problems, "expires is required")
+	} else if expires, err := time.Parse(DateFormat, e.Expires); err != nil {
+		problems = append(problems, fmt.Sprintf("expires %q is not a %s date", e.Expires, DateFormat))
+	} else {
+		e.expires = expires
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Expired reports whether the entry no longer applies at now
+// The expiry date itself is still covered
+func (e Entry) Expired(now time.Time) bool {
+	return !now.UTC().Before(e.expires.AddDate(0, 0, 1))
+}
+
+// Active returns the entries still in effect at now
+func (l *List) Active(now time.Time) *List {
+	if l == nil {
+		return nil
+	}
+	active := &List{}
+	for _, entry := range l.Entries {
+		if !entry.Expired(now) {
+			active.Entries = append(active.Entries, entry)
+		}
+	}
+	return active
+}
+
+// Expired returns the entries past their expiry date at now
+func (l *List) Expired(now time.Time) []Entry {
+	if l == nil {
+		return nil
+	}
+	var expired []Entry
+	for _, entry := range l.Entries {
+		if entry.Expired(now) {
+			expired = append(expired, entry)
+		}
+	}
+	return expired
+}
+
+// Match returns the first entry whose regex matches the spec full text
+func (l *List) Match(fullText string) (Entry, bool) {
+	if l == nil {
+		return Entry{}, false
+	}
+	for _, entry := range l.Entries {
+		if entry.pattern.MatchString(fullText) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
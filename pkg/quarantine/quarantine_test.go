@@ -0,0 +1,123 @@
+package quarantine
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr []string
+	}{
+		{
+			name: "valid entries",
+			content: `
+quarantine:
+  - spec: "Cluster .* reaches Ready"
+    ticket: HYPERFLEET-123
+    expires: 2026-11-30
+  - spec: "NodePool"
+    ticket: HYPERFLEET-456
+    expires: "2026-12-31"
+`,
+			want: 2,
+		},
+		{
+			name:    "empty file",
+			content: "",
+			want:    0,
+		},
+		{
+			name: "invalid entries reported together",
+			content: `
+quarantine:
+  - spec: "Cluster ("
+    ticket: HYPERFLEET-123
+    expires: 2026-11-30
+  - spec: "NodePool"
+    expires: 30/11/2026
+`,
+			wantErr: []string{"entry 1: spec is not a valid regex", "entry 2: ticket is required", `expires "30/11/2026"`},
+		},
+		{
+			name: "unknown field",
+			content: `
+quarantine:
+  - spec: "Cluster"
+    ticket: HYPERFLEET-123
+    expiry: 2026-11-30
+`,
+			wantErr: []string{"expiry"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := Parse([]byte(tt.content))
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatalf("Parse() succeeded, want error")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("Parse() error %q does not contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if len(list.Entries) != tt.want {
+				t.Errorf("Parse() returned %d entries, want %d", len(list.Entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveAndExpired(t *testing.T) {
+	list, err := Parse([]byte(`
+quarantine:
+  - spec: "Cluster"
+    ticket: HYPERFLEET-1
+    expires: 2026-10-15
+  - spec: "NodePool"
+    ticket: HYPERFLEET-2
+    expires: 2026-10-16
+`))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	// The expiry date itself is still covered
+	now := time.Date(2026, 10, 16, 23, 59, 0, 0, time.UTC)
+
+	expired := list.Expired(now)
+	if len(expired) != 1 || expired[0].Ticket != "HYPERFLEET-1" {
+		t.Errorf("Expired() = %v, want only HYPERFLEET-1", expired)
+	}
+
+	active := list.Active(now)
+	if _, ok := active.Match("[Suite: cluster] Cluster lifecycle"); ok {
+		t.Errorf("expired entry still matches")
+	}
+	entry, ok := active.Match("[Suite: nodepool] NodePool lifecycle")
+	if !ok || entry.Ticket != "HYPERFLEET-2" {
+		t.Errorf("Match() = %v, %v, want HYPERFLEET-2", entry, ok)
+	}
+
+	if len(list.Active(now.Add(time.Minute)).Entries) != 0 {
+		t.Errorf("expected all entries to expire after 2026-10-16")
+	}
+}
+
+func TestNilListMatchesNothing(t *testing.T) {
+	var list *List
+	if _, ok := list.Match("anything"); ok {
+		t.Errorf("nil list matched")
+	}
+}
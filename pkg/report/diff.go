@@ -0,0 +1,220 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DiffOptions configures Compare
+type DiffOptions struct {
+	// Threshold is the relative duration increase reported as a regression, e.g. 0.3 for 30%
+	Threshold float64
+	// MinDurationSeconds ignores specs and steps whose baseline duration is shorter, they are too noisy to compare
+	MinDurationSeconds float64
+}
+
+// Diff lists the differences between a baseline and a candidate report set
+type Diff struct {
+	NewFailures []Result // failed the candidate run, passed, absent or did not block in the baseline
+	NonBlocking []Result // failed without blocking (informing, flaky, quarantined), passed or absent in the baseline
+	Fixed       []Result // failed in the baseline, passed in the candidate
+	Added       []Result // in the candidate only
+	Removed     []Result // in the baseline only
+	Slower      []DurationChange
+}
+
+// DurationChange is a spec or ginkgo.By step that got slower
+type DurationChange struct {
+	Spec             string
+	Step             string // empty for the whole spec
+	BaselineSeconds  float64
+	CandidateSeconds float64
+}
+
+// Increase is the relative duration increase, e.g. 0.5 for 50% slower
+func (c DurationChange) Increase() float64 {
+	return c.CandidateSeconds/c.BaselineSeconds - 1
+}
+
+// Regressed reports whether the candidate has new blocking failures or duration regressions
+func (d Diff) Regressed() bool {
+	return len(d.NewFailures) > 0 || len(d.Slower) > 0
+}
+
+// LoadResultSet reads the results of a report file, or of all reports in a directory:
+// the Ginkgo JSON reports (report*.json) if there are any, otherwise the JUnit reports (*.xml)
+func LoadResultSet(path string) ([]Result, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report set: %w", err)
+	}
+	if !info.IsDir() {
+		return LoadResults(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "report*.json"))
+	if err == nil && len(files) == 0 {
+		files, err = filepath.Glob(filepath.Join(path, "*.xml"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports in %s: %w", path, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no report*.json or *.xml reports in %s", path)
+	}
+
+	var results []Result
+	for _, file := range files {
+		loaded, err := LoadResults(file)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, loaded...)
+	}
+	return results, nil
+}
+
+// Compare lists new failures, fixed, added and removed specs, and spec and step duration regressions
+// Specs are matched by name; a spec appearing in several reports of a set keeps its most informative
+// result, a failure over a run over a skip, the way shard reports are merged.
+func Compare(baseline, candidate []Result, opts DiffOptions) Diff {
+	base, baseOrder := indexResults(baseline)
+	cand, candOrder := indexResults(candidate)
+
+	var diff Diff
+	for _, name := range candOrder {
+		c := cand[name]
+		b, inBaseline := base[name]
+		switch {
+		case !inBaseline:
+			diff.Added = append(diff.Added, c)
+			switch {
+			case c.blockingFailure():
+				diff.NewFailures = append(diff.NewFailures, c)
+			case c.Failed():
+				diff.NonBlocking = append(diff.NonBlocking, c)
+			}
+		case c.blockingFailure() && !b.blockingFailure():
+			diff.NewFailures = append(diff.NewFailures, c)
+		case c.Failed() && !b.Failed():
+			diff.NonBlocking = append(diff.NonBlocking, c)
+		case b.Failed() && c.State == StatusPassed:
+			diff.Fixed = append(diff.Fixed, c)
+		case b.State == StatusPassed && c.State == StatusPassed:
+			diff.Slower = append(diff.Slower, slowdowns(b, c, opts)...)
+		}
+	}
+	for _, name := range baseOrder {
+		if _, ok := cand[name]; !ok {
+			diff.Removed = append(diff.Removed, base[name])
+		}
+	}
+	return diff
+}
+
+// indexResults indexes results by name, keeping the most informative result of duplicates
+func indexResults(results []Result) (map[string]Result, []string) {
+	index := make(map[string]Result)
+	var order []string
+	for _, result := range results {
+		existing, ok := index[result.Name]
+		if !ok {
+			order = append(order, result.Name)
+		}
+		if !ok || resultRank(result) > resultRank(existing) {
+			index[result.Name] = result
+		}
+	}
+	sort.Strings(order)
+	return index, order
+}
+
+// resultRank orders results like outcomeRank orders JUnit test cases
+func resultRank(result Result) int {
+	switch {
+	case result.Failed():
+		return 2
+	case result.State == StatusPassed:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// slowdowns compares the duration of a passed spec and of its steps, matched by step text
+func slowdowns(baseline, candidate Result, opts DiffOptions) []DurationChange {
+	var changes []DurationChange
+	if change, ok := slowdown(candidate.Name, "", baseline.DurationSeconds, candidate.DurationSeconds, opts); ok {
+		changes = append(changes, change)
+	}
+
+	baseSteps, stepOrder := stepDurations(baseline.Steps)
+	candSteps, _ := stepDurations(candidate.Steps)
+	for _, step := range stepOrder {
+		candSeconds, ok := candSteps[step]
+		if !ok {
+			continue
+		}
+		if change, ok := slowdown(candidate.Name, step, baseSteps[step], candSeconds, opts); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func slowdown(spec, step string, baseSeconds, candSeconds float64, opts DiffOptions) (DurationChange, bool) {
+	if baseSeconds <= 0 || baseSeconds < opts.MinDurationSeconds {
+		return DurationChange{}, false
+	}
+	change := DurationChange{Spec: spec, Step: step, BaselineSeconds: baseSeconds, CandidateSeconds: candSeconds}
+	return change, change.Increase() > opts.Threshold
+}
+
+// stepDurations sums the durations of steps by text, steps repeated in a loop count together
+func stepDurations(steps []Step) (map[string]float64, []string) {
+	durations := make(map[string]float64)
+	var order []string
+	for _, step := range steps {
+		if _, ok := durations[step.Text]; !ok {
+			order = append(order, step.Text)
+		}
+		durations[step.Text] += step.DurationSeconds
+	}
+	return durations, order
+}
+
+// RenderDiff prints a diff as text
+func RenderDiff(w io.Writer, diff Diff, opts DiffOptions) {
+	writeResults(w, "New failures", diff.NewFailures, func(r Result) string {
+		if r.FailureLocation != "" {
+			return fmt.Sprintf("%s (%s at %s)", r.Name, r.State, r.FailureLocation)
+		}
+		return fmt.Sprintf("%s (%s)", r.Name, r.State)
+	})
+	writeResults(w, "New non-blocking failures", diff.NonBlocking, func(r Result) string {
+		return fmt.Sprintf("[%s] %s (%s)", r.NonBlocking, r.Name, r.State)
+	})
+	writeResults(w, "Fixed", diff.Fixed, func(r Result) string { return r.Name })
+	writeResults(w, "Added specs", diff.Added, func(r Result) string { return fmt.Sprintf("%s (%s)", r.Name, r.State) })
+	writeResults(w, "Removed specs", diff.Removed, func(r Result) string { return r.Name })
+
+	_, _ = fmt.Fprintf(w, "Duration regressions over %.0f%% (%d):\n", opts.Threshold*100, len(diff.Slower))
+	for _, change := range diff.Slower {
+		name := change.Spec
+		if change.Step != "" {
+			name += " > " + change.Step
+		}
+		_, _ = fmt.Fprintf(w, "  - %s: %s -> %s (+%.0f%%)\n", name,
+			formatSeconds(change.BaselineSeconds), formatSeconds(change.CandidateSeconds), change.Increase()*100)
+	}
+}
+
+func writeResults(w io.Writer, title string, results []Result, format func(Result) string) {
+	_, _ = fmt.Fprintf(w, "%s (%d):\n", title, len(results))
+	for _, result := range results {
+		_, _ = fmt.Fprintf(w, "  - %s\n", format(result))
+	}
+}
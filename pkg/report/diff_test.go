@@ -0,0 +1,165 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func result(name, state string, seconds float64, steps ...Step) Result {
+	return Result{Name: name, State: state, DurationSeconds: seconds, Steps: steps}
+}
+
+func names(results []Result) []string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.Name)
+	}
+	return out
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		result("stays green", StatusPassed, 60, Step{Text: "wait for Ready", DurationSeconds: 40}),
+		result("breaks", StatusPassed, 10),
+		result("gets fixed", StatusFailed, 10),
+		result("removed", StatusPassed, 10),
+		result("quick", StatusPassed, 1),
+		// Another shard skipped the spec that ran in this one
+		result("sharded", StatusSkipped, 0),
+		result("sharded", StatusPassed, 20),
+	}
+	candidate := []Result{
+		result("stays green", StatusPassed, 70, Step{Text: "wait for Ready", DurationSeconds: 60}),
+		result("breaks", StatusTimedout, 10),
+		result("gets fixed", StatusPassed, 10),
+		result("added", StatusPassed, 10),
+		result("added and failing", StatusFailed, 10),
+		result("quick", StatusPassed, 3),
+		result("sharded", StatusPassed, 40),
+	}
+
+	diff := Compare(baseline, candidate, DiffOptions{Threshold: 0.3, MinDurationSeconds: 5})
+
+	for _, check := range []struct {
+		name string
+		got  []Result
+		want string
+	}{
+		{"new failures", diff.NewFailures, "added and failing,breaks"},
+		{"fixed", diff.Fixed, "gets fixed"},
+		{"added", diff.Added, "added,added and failing"},
+		{"removed", diff.Removed, "removed"},
+	} {
+		if got := strings.Join(names(check.got), ","); got != check.want {
+			t.Errorf("%s = %q, want %q", check.name, got, check.want)
+		}
+	}
+
+	// "stays green" took 17% longer but its wait step 50% longer; "quick" is below the minimum duration
+	if len(diff.Slower) != 2 {
+		t.Fatalf("Slower = %+v, want the sharded spec and the wait step", diff.Slower)
+	}
+	if s := diff.Slower[0]; s.Spec != "sharded" || s.Step != "" || s.Increase() != 1 {
+		t.Errorf("first slowdown = %+v, want the sharded spec doubling", s)
+	}
+	if s := diff.Slower[1]; s.Spec != "stays green" || s.Step != "wait for Ready" || s.Increase() != 0.5 {
+		t.Errorf("second slowdown = %+v, want the wait step 50%% slower", s)
+	}
+	if !diff.Regressed() {
+		t.Error("Regressed() = false, want true")
+	}
+
+	if Compare(baseline, baseline, DiffOptions{Threshold: 0.3}).Regressed() {
+		t.Error("a run compared with itself regressed")
+	}
+}
+
+func TestCompareNonBlockingFailures(t *testing.T) {
+	nonBlocking := func(name, reason string) Result {
+		r := result(name, StatusFailed, 10)
+		r.NonBlocking = reason
+		return r
+	}
+	baseline := []Result{
+		result("informing breaks", StatusPassed, 10),
+		result("quarantined breaks", StatusPassed, 10),
+		nonBlocking("promoted to stable", "informing"),
+	}
+	candidate := []Result{
+		nonBlocking("informing breaks", "informing"),
+		nonBlocking("quarantined breaks", "quarantined"),
+		result("promoted to stable", StatusFailed, 10),
+		nonBlocking("added flaky", "flaky"),
+	}
+
+	diff := Compare(baseline, candidate, DiffOptions{Threshold: 0.3})
+	if got := strings.Join(names(diff.NewFailures), ","); got != "promoted to stable" {
+		t.Errorf("NewFailures = %q, want the spec that now blocks", got)
+	}
+	if got := strings.Join(names(diff.NonBlocking), ","); got != "added flaky,informing breaks,quarantined breaks" {
+		t.Errorf("NonBlocking = %q, want the failures that do not block", got)
+	}
+
+	candidate = candidate[:2]
+	if diff := Compare(baseline[:2], candidate, DiffOptions{Threshold: 0.3}); diff.Regressed() {
+		t.Errorf("Regressed() = true with only non-blocking failures: %+v", diff)
+	}
+}
+
+func TestLoadResultSetFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for i, state := range []types.SpecState{types.SpecStatePassed, types.SpecStateSkipped} {
+		spec := summarySpec("creates a cluster", state, "tier0")
+		path := filepath.Join(dir, fmt.Sprintf("report-shard-%d.json", i))
+		if err := reporters.GenerateJSONReport(types.Report{SpecReports: types.SpecReports{spec}}, path); err != nil {
+			t.Fatalf("GenerateJSONReport() error: %v", err)
+		}
+	}
+	// Summaries and JUnit files next to JSON reports are not read
+	if err := os.WriteFile(filepath.Join(dir, SummaryFile), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "results.xml"), []byte("not xml"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := LoadResultSet(dir)
+	if err != nil {
+		t.Fatalf("LoadResultSet() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("LoadResultSet() = %d results, want one per shard report", len(results))
+	}
+
+	if _, err := LoadResultSet(t.TempDir()); err == nil {
+		t.Error("LoadResultSet() of an empty directory succeeded, want error")
+	}
+}
+
+func TestRenderDiff(t *testing.T) {
+	diff := Diff{
+		NewFailures: []Result{{Name: "breaks", State: StatusFailed, FailureLocation: "/src/e2e/a.go:7"}},
+		NonBlocking: []Result{{Name: "wobbles", State: StatusFailed, NonBlocking: "flaky"}},
+		Slower:      []DurationChange{{Spec: "creates", Step: "wait for Ready", BaselineSeconds: 40, CandidateSeconds: 60}},
+	}
+	var out bytes.Buffer
+	RenderDiff(&out, diff, DiffOptions{Threshold: 0.3})
+
+	for _, want := range []string{
+		"New failures (1):\n  - breaks (failed at /src/e2e/a.go:7)",
+		"New non-blocking failures (1):\n  - [flaky] wobbles (failed)",
+		"Fixed (0):",
+		"Duration regressions over 30% (1):\n  - creates > wait for Ready: 40s -> 1m0s (+50%)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("diff output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package report
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/labels"
+)
+
+//go:embed html.tmpl
+var htmlTemplate string
+
+var htmlPageTemplate = template.Must(template.New("report").Parse(htmlTemplate))
+
+// HTMLOptions configures WriteHTML
+type HTMLOptions struct {
+	Title string
+	// ArtifactsDir is the output directory artifact paths are relative to
+	// Empty means the directory of the report each result was read from
+	ArtifactsDir string
+}
+
+// htmlPage is the data rendered by html.tmpl
+type htmlPage struct {
+	Title     string
+	Generated time.Time
+	Runs      []htmlRun
+	Suites    []string
+	Tiers     []string
+	Labels    []string
+	States    []string
+	Specs     []htmlSpec
+}
+
+type htmlRun struct {
+	Name                           string
+	Total, Passed, Failed, Skipped int
+}
+
+type htmlSpec struct {
+	Result
+	Run       string
+	Tier      string
+	Class     string
+	LabelList string
+	Duration  string
+	Steps     []htmlStep
+	Artifacts []htmlLink
+}
+
+type htmlStep struct {
+	Text, Start, Duration string
+	Left, Width           string // position on the spec timeline, in percent
+}
+
+type htmlLink struct {
+	Name, Href string
+}
+
+// WriteHTML renders the results of one or more reports as a single self-contained HTML file
+func WriteHTML(path string, results []Result, opts HTMLOptions) error {
+	page := newHTMLPage(path, results, opts)
+
+	var buf bytes.Buffer
+	if err := htmlPageTemplate.Execute(&buf, page); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+func newHTMLPage(path string, results []Result, opts HTMLOptions) htmlPage {
+	page := htmlPage{Title: opts.Title, Generated: time.Now()}
+	if page.Title == "" {
+		page.Title = "HyperFleet E2E Report"
+	}
+
+	runs := make(map[string]*htmlRun)
+	var runOrder []string
+	runNames := reportRunNames(results)
+	suites, tiers, labelSet, states := map[string]bool{}, map[string]bool{}, map[string]bool{}, map[string]bool{}
+
+	for _, result := range results {
+		spec := htmlSpec{
+			Result:    result,
+			Run:       runNames[result.Source],
+			Class:     stateClass(result),
+			LabelList: strings.Join(result.Labels, " "),
+			Duration:  formatSeconds(result.DurationSeconds),
+			Steps:     htmlSteps(result),
+			Artifacts: artifactLinks(path, result, opts.ArtifactsDir),
+		}
+		for _, label := range result.Labels {
+			switch label {
+			case labels.Tier0, labels.Tier1, labels.Tier2:
+				spec.Tier = label
+				tiers[label] = true
+			default:
+				labelSet[label] = true
+			}
+		}
+		if result.Suite != "" {
+			suites[result.Suite] = true
+		}
+		states[result.State] = true
+
+		run, ok := runs[spec.Run]
+		if !ok {
+			run = &htmlRun{Name: spec.Run}
+			runs[spec.Run] = run
+			runOrder = append(runOrder, spec.Run)
+		}
+		run.Total++
+		switch {
+		case result.Failed():
+			run.Failed++
+		case result.State == StatusPassed:
+			run.Passed++
+		default:
+			run.Skipped++
+		}
+
+		page.Specs = append(page.Specs, spec)
+	}
+
+	for _, name := range runOrder {
+		page.Runs = append(page.Runs, *runs[name])
+	}
+	page.Suites, page.Tiers, page.Labels, page.States = sortedKeys(suites), sortedKeys(tiers), sortedKeys(labelSet), sortedKeys(states)
+
+	// Failures first, then by suite and name
+	sort.SliceStable(page.Specs, func(i, j int) bool {
+		a, b := page.Specs[i], page.Specs[j]
+		if a.Failed() != b.Failed() {
+			return a.Failed()
+		}
+		if a.Suite != b.Suite {
+			return a.Suite < b.Suite
+		}
+		return a.Name < b.Name
+	})
+	return page
+}
+
+// reportRunNames names each report by its path relative to the directory all reports share,
+// so reports with the same file name in different directories stay separate runs
+func reportRunNames(results []Result) map[string]string {
+	names := make(map[string]string)
+	common := ""
+	for _, result := range results {
+		if _, ok := names[result.Source]; ok {
+			continue
+		}
+		names[result.Source] = ""
+		dir := filepath.Dir(filepath.Clean(result.Source))
+		if len(names) == 1 {
+			common = dir
+			continue
+		}
+		for !isWithinDir(dir, common) && filepath.Dir(common) != common {
+			common = filepath.Dir(common)
+		}
+	}
+
+	for source := range names {
+		name, err := filepath.Rel(common, filepath.Clean(source))
+		if err != nil || strings.HasPrefix(name, "..") {
+			// Relative and absolute paths share no directory, keep the path as given
+			name = source
+		}
+		names[source] = name
+	}
+	return names
+}
+
+// isWithinDir reports whether path is dir or inside of it
+func isWithinDir(path, dir string) bool {
+	if dir == "." {
+		return !filepath.IsAbs(path) && path != ".." && !strings.HasPrefix(path, ".."+string(filepath.Separator))
+	}
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator))
+}
+
+// stateClass groups spec states into the CSS classes passed, failed and skipped
+func stateClass(result Result) string {
+	switch {
+	case result.Failed():
+		return "failed"
+	case result.State == StatusPassed:
+		return "passed"
+	default:
+		return "skipped"
+	}
+}
+
+func htmlSteps(result Result) []htmlStep {
+	total := result.DurationSeconds
+	steps := make([]htmlStep, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		left, width := 0.0, 0.0
+		if total > 0 {
+			left = min(100, step.OffsetSeconds/total*100)
+			width = min(100-left, step.DurationSeconds/total*100)
+		}
+		steps = append(steps, htmlStep{
+			Text:     step.Text,
+			Start:    formatSeconds(step.OffsetSeconds),
+			Duration: formatSeconds(step.DurationSeconds),
+			Left:     strconv.FormatFloat(left, 'f', 1, 64),
+			Width:    strconv.FormatFloat(width, 'f', 1, 64),
+		})
+	}
+	return steps
+}
+
+// artifactLinks resolves artifact paths, relative to the output directory, to links relative to the HTML file
+func artifactLinks(htmlPath string, result Result, artifactsDir string) []htmlLink {
+	if artifactsDir == "" {
+		artifactsDir = filepath.Dir(result.Source)
+	}
+	links := make([]htmlLink, 0, len(result.Artifacts))
+	for _, artifact := range result.Artifacts {
+		target := artifact
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(artifactsDir, artifact)
+		}
+		href := target
+		absTarget, errTarget := filepath.Abs(target)
+		absDir, errDir := filepath.Abs(filepath.Dir(htmlPath))
+		if errTarget == nil && errDir == nil {
+			if rel, err := filepath.Rel(absDir, absTarget); err == nil {
+				href = rel
+			}
+		}
+		links = append(links, htmlLink{Name: artifact, Href: filepath.ToSlash(href)})
+	}
+	return links
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	dir := t.TempDir()
+	results := []Result{
+		{
+			Source:          filepath.Join(dir, "output", JSONReportFile),
+			Suite:           "cluster",
+			Name:            "[Suite: cluster] creates a <cluster>",
+			Labels:          []string{"tier0", "slow"},
+			State:           StatusPassed,
+			DurationSeconds: 10,
+			Steps:           []Step{{Text: "wait for Ready", OffsetSeconds: 2, DurationSeconds: 5}},
+			Output:          "cluster created",
+			Artifacts:       []string{"adapter-ab12"},
+		},
+		{
+			Source:  filepath.Join(dir, "output", JSONReportFile),
+			Suite:   "adapter",
+			Name:    "[Suite: adapter] reports failure",
+			Labels:  []string{"tier1"},
+			State:   StatusFailed,
+			Failure: "expected False",
+		},
+	}
+
+	path := filepath.Join(dir, "html", "report.html")
+	if err := WriteHTML(path, results, HTMLOptions{}); err != nil {
+		t.Fatalf("WriteHTML() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read HTML report: %v", err)
+	}
+	page := string(data)
+
+	for _, want := range []string{
+		`data-suite="cluster" data-tier="tier0" data-labels="tier0 slow" data-state="passed"`,
+		`<option>slow</option>`,
+		`creates a &lt;cluster&gt;`,
+		`<a href="../output/adapter-ab12">adapter-ab12</a>`,
+		`left: 20.0%; width: 50.0%`,
+		`<pre>cluster created</pre>`,
+		`expected False`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML report does not contain %q", want)
+		}
+	}
+
+	// Failures are listed first
+	if strings.Index(page, "reports failure") > strings.Index(page, "creates a &lt;cluster&gt;") {
+		t.Errorf("failed spec is not listed before the passed one")
+	}
+
+	// The page must work offline
+	for _, external := range []string{"src=\"http", "href=\"http", "@import"} {
+		if strings.Contains(page, external) {
+			t.Errorf("HTML report references external assets (%s)", external)
+		}
+	}
+}
+
+func TestHTMLRunsOfReportsWithTheSameName(t *testing.T) {
+	dir := t.TempDir()
+	results := []Result{
+		{Source: filepath.Join(dir, "runs", "a", JSONReportFile), Name: "spec one", State: StatusPassed},
+		{Source: filepath.Join(dir, "runs", "b", JSONReportFile), Name: "spec one", State: StatusFailed},
+		{Source: filepath.Join(dir, "runs", "b", JSONReportFile), Name: "spec two", State: StatusPassed},
+	}
+
+	page := newHTMLPage(filepath.Join(dir, "report.html"), results, HTMLOptions{})
+
+	want := []htmlRun{
+		{Name: filepath.Join("a", JSONReportFile), Total: 1, Passed: 1},
+		{Name: filepath.Join("b", JSONReportFile), Total: 2, Passed: 1, Failed: 1},
+	}
+	if !reflect.DeepEqual(page.Runs, want) {
+		t.Errorf("Runs = %+v, want %+v", page.Runs, want)
+	}
+
+	names := reportRunNames([]Result{{Source: filepath.Join(dir, JSONReportFile)}})
+	if got := names[filepath.Join(dir, JSONReportFile)]; got != JSONReportFile {
+		t.Errorf("run name of a single report = %q, want %q", got, JSONReportFile)
+	}
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+)
+
+// JUnit test case statuses written by Ginkgo (types.SpecState strings)
+const (
+	StatusPassed      = "passed"
+	StatusSkipped     = "skipped"
+	StatusPending     = "pending"
+	StatusFailed      = "failed"
+	StatusTimedout    = "timedout"
+	StatusInterrupted = "interrupted"
+	StatusAborted     = "aborted"
+	StatusPanicked    = "panicked"
+)
+
+// Test case properties added to the JUnit report by the test command
+const (
+	PropertyAttempts = "attempts" // how many times the spec ran
+	PropertyArtifact = "artifact" // a file or directory the spec saved, relative to the output directory
+)
+
+// Test suite properties added to the JUnit report by the test command
+const (
+	PropertyFrameworkVersion   = "hyperfleet-e2e.version"
+	PropertyFrameworkCommit    = "hyperfleet-e2e.commit"
+	PropertyOpenAPISpecVersion = "openapi.specVersion" // spec the API client was generated from
+	PropertyOpenAPISpecHash    = "openapi.specHash"
+	PropertyServerAPIVersion   = "api.specVersion" // OpenAPI document served by the API under test
+	PropertyServerAPIHash      = "api.specHash"
+)
+
+// ReportEntryArtifact is the Ginkgo report entry name under which specs record the artifacts they saved
+const ReportEntryArtifact = "artifact"
+
+// ReportEntryNonBlocking is the Ginkgo JSON report entry recording why a failed spec did not fail the run
+// ("informing", "flaky" or "quarantined"). JUnit reports carry the reason as prefix of the failure type instead,
+// e.g. "informing-failed".
+const ReportEntryNonBlocking = "nonBlocking"
+
+// JUnitTestSuites mirrors reporters.JUnitTestSuites with test case properties, which Ginkgo does not emit
+type JUnitTestSuites struct {
+	reporters.JUnitTestSuites
+	TestSuites []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite mirrors reporters.JUnitTestSuite with test case properties
+type JUnitTestSuite struct {
+	reporters.JUnitTestSuite
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a Ginkgo test case with optional properties
+type JUnitTestCase struct {
+	Properties *reporters.JUnitProperties `xml:"properties,omitempty"`
+	reporters.JUnitTestCase
+}
+
+// Property returns the value of a test case property, or "" if it is not set
+func (tc JUnitTestCase) Property(name string) string {
+	if tc.Properties == nil {
+		return ""
+	}
+	return tc.Properties.WithName(name)
+}
+
+// SetProperty adds a test case property
+func (tc *JUnitTestCase) SetProperty(name, value string) {
+	if tc.Properties == nil {
+		tc.Properties = &reporters.JUnitProperties{}
+	}
+	tc.Properties.Properties = append(tc.Properties.Properties, reporters.JUnitProperty{Name: name, Value: value})
+}
+
+// ReadJUnit parses a JUnit XML file
+func ReadJUnit(path string) (*JUnitTestSuites, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a report location chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JUnit report: %w", err)
+	}
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit report %s: %w", path, err)
+	}
+	return &suites, nil
+}
+
+// WriteJUnit writes a JUnit document the way Ginkgo does
+func WriteJUnit(path string, suites *JUnitTestSuites) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	f, err := os.Create(path) // #nosec G304 -- path is a report location chosen by the user
+	if err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	_, _ = f.WriteString(xml.Header)
+	encoder := xml.NewEncoder(f)
+	encoder.Indent("  ", "    ")
+	if err := encoder.Encode(suites); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WriteMarkdown writes the Markdown rendering of a summary, suitable for a PR comment
+func WriteMarkdown(path string, summary Summary) error {
+	var buf bytes.Buffer
+	RenderMarkdown(&buf, summary)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write Markdown summary: %w", err)
+	}
+	return nil
+}
+
+// RenderMarkdown renders the totals, failures and the per-spec step timeline of a summary
+func RenderMarkdown(w io.Writer, summary Summary) {
+	result := "PASSED"
+	if !summary.Passed {
+		result = "FAILED"
+	}
+	_, _ = fmt.Fprintf(w, "## %s: %s\n\n", summary.Suite, result)
+
+	totals := summary.Totals
+	_, _ = fmt.Fprintf(w, "Ran %d specs in %s", totals.Total-totals.Skipped-totals.Pending, formatSeconds(summary.DurationSeconds))
+	if summary.LabelFilter != "" {
+		_, _ = fmt.Fprintf(w, " with label filter `%s`", summary.LabelFilter)
+	}
+	if summary.RunID != "" {
+		_, _ = fmt.Fprintf(w, " (run ID `%s`)", summary.RunID)
+	}
+	_, _ = fmt.Fprint(w, "\n\n")
+	if v := summary.Versions; v != nil {
+		_, _ = fmt.Fprintf(w, "hyperfleet-e2e `%s` (commit `%s`), client OpenAPI spec `%s`",
+			v.Framework.Version, v.Framework.Commit, v.Framework.OpenAPISpecVersion)
+		if v.ServerAPI != nil {
+			_, _ = fmt.Fprintf(w, ", server API `%s`", v.ServerAPI.SpecVersion)
+		}
+		_, _ = fmt.Fprint(w, "\n\n")
+	}
+
+	_, _ = fmt.Fprintln(w, "| Label | Passed | Failed | Non-blocking failed | Passed on retry | Skipped |")
+	_, _ = fmt.Fprintln(w, "|---|---:|---:|---:|---:|---:|")
+	writeCountsRow(w, "**all**", totals)
+	labels := make([]string, 0, len(summary.Labels))
+	for label := range summary.Labels {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		writeCountsRow(w, "`"+label+"`", summary.Labels[label])
+	}
+
+	if len(summary.Failures) > 0 {
+		_, _ = fmt.Fprint(w, "\n### Failures\n\n")
+		for _, failure := range summary.Failures {
+			name := failure.Name
+			if failure.NonBlocking != "" {
+				name = "[" + failure.NonBlocking + "] " + name
+			}
+			_, _ = fmt.Fprintf(w, "- **%s** (%s) at `%s`\n", escapeMarkdown(name), failure.State, failure.FailureLocation)
+			if message := strings.TrimSpace(failure.Message); message != "" {
+				_, _ = fmt.Fprintf(w, "  ```\n%s\n  ```\n", indent(message, "  "))
+			}
+		}
+	}
+
+	if summary.Interrupted {
+		_, _ = fmt.Fprint(w, "\n### Cleanup after interrupt\n\n")
+		if len(summary.Cleanups) == 0 {
+			_, _ = fmt.Fprintln(w, "No resources created by specs were recorded.")
+		}
+		for _, cleanup := range summary.Cleanups {
+			check := "x"
+			if !cleanup.Completed {
+				check = " "
+			}
+			_, _ = fmt.Fprintf(w, "- [%s] %s `%s`", check, cleanup.Kind, cleanup.Name)
+			if cleanup.Error != "" {
+				_, _ = fmt.Fprintf(w, ": %s", escapeMarkdown(cleanup.Error))
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+
+	timeline := false
+	for _, spec := range summary.Specs {
+		if spec.State == StatusSkipped || spec.State == StatusPending {
+			continue
+		}
+		if !timeline {
+			_, _ = fmt.Fprint(w, "\n### Timeline\n")
+			timeline = true
+		}
+		_, _ = fmt.Fprintf(w, "\n<details><summary>%s %s: %s</summary>\n\n",
+			strings.ToUpper(spec.State), formatSeconds(spec.DurationSeconds), escapeMarkdown(spec.Name))
+		if len(spec.Steps) == 0 {
+			_, _ = fmt.Fprint(w, "No steps recorded.\n")
+		} else {
+			_, _ = fmt.Fprintln(w, "| Start | Duration | Step |")
+			_, _ = fmt.Fprintln(w, "|---:|---:|---|")
+			for _, step := range spec.Steps {
+				_, _ = fmt.Fprintf(w, "| +%s | %s | %s |\n",
+					formatSeconds(step.OffsetSeconds), formatSeconds(step.DurationSeconds), escapeMarkdown(step.Text))
+			}
+		}
+		_, _ = fmt.Fprint(w, "\n</details>\n")
+	}
+}
+
+func writeCountsRow(w io.Writer, name string, counts Counts) {
+	_, _ = fmt.Fprintf(w, "| %s | %d | %d | %d | %d | %d |\n",
+		name, counts.Passed, counts.Failed, counts.NonBlockingFailed, counts.PassedOnRetry, counts.Skipped+counts.Pending)
+}
+
+// formatSeconds formats seconds as a Go duration rounded to a tenth of a second, e.g. 1m2.5s
+func formatSeconds(s float64) string {
+	return time.Duration(s * float64(time.Second)).Round(100 * time.Millisecond).String()
+}
+
+// escapeMarkdown keeps spec text from breaking tables and HTML summaries
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", `\|`, "<", "&lt;", ">", "&gt;", "\n", " ").Replace(text)
+}
+
+func indent(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
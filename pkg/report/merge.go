@@ -0,0 +1,108 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Merge combines the JUnit reports of several shards of the same suite into one document.
+// Every shard lists all filtered specs and skips those of other shards, so test cases with the
+// same name are collapsed into the one that carries the most information: a failure over a run
+// over a skip. Suite setup nodes run in every shard and keep their worst outcome.
+func Merge(inputs []*JUnitTestSuites) (*JUnitTestSuites, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no reports to merge")
+	}
+
+	merged := &JUnitTestSuites{}
+	suiteIndex := make(map[string]int)
+	caseIndex := make(map[string]map[string]int)
+
+	for _, input := range inputs {
+		for _, suite := range input.TestSuites {
+			i, ok := suiteIndex[suite.Name]
+			if !ok {
+				i = len(merged.TestSuites)
+				suiteIndex[suite.Name] = i
+				caseIndex[suite.Name] = make(map[string]int)
+
+				first := suite
+				first.TestCases = nil
+				merged.TestSuites = append(merged.TestSuites, first)
+			} else {
+				mergeSuiteAttributes(&merged.TestSuites[i], suite)
+			}
+
+			target := &merged.TestSuites[i]
+			cases := caseIndex[suite.Name]
+			for _, testCase := range suite.TestCases {
+				j, seen := cases[testCase.Name]
+				if !seen {
+					cases[testCase.Name] = len(target.TestCases)
+					target.TestCases = append(target.TestCases, testCase)
+					continue
+				}
+				if outcomeRank(testCase) > outcomeRank(target.TestCases[j]) {
+					target.TestCases[j] = testCase
+				}
+			}
+		}
+	}
+
+	for i := range merged.TestSuites {
+		recount(&merged.TestSuites[i])
+		suite := merged.TestSuites[i]
+		merged.Tests += suite.Tests
+		merged.Disabled += suite.Disabled + suite.Skipped
+		merged.Errors += suite.Errors
+		merged.Failures += suite.Failures
+		merged.Time += suite.Time
+	}
+	return merged, nil
+}
+
+// mergeSuiteAttributes folds the run time and start time of another shard into a merged suite
+func mergeSuiteAttributes(target *JUnitTestSuite, suite JUnitTestSuite) {
+	target.Time += suite.Time
+	if suite.Timestamp != "" && (target.Timestamp == "" || suite.Timestamp < target.Timestamp) {
+		target.Timestamp = suite.Timestamp
+	}
+}
+
+// outcomeRank orders test case outcomes by how much they tell about the spec
+func outcomeRank(testCase JUnitTestCase) int {
+	switch {
+	case testCase.Failure != nil || testCase.Error != nil:
+		return 2
+	case testCase.Skipped == nil:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// recount recomputes the suite counters from its test cases the way Ginkgo computes them
+func recount(suite *JUnitTestSuite) {
+	suite.Tests = len(suite.TestCases)
+	suite.Disabled, suite.Skipped, suite.Errors, suite.Failures = 0, 0, 0, 0
+
+	for _, testCase := range suite.TestCases {
+		switch testCase.Status {
+		case StatusSkipped:
+			suite.Skipped++
+		case StatusPending:
+			suite.Disabled++
+		case StatusFailed, StatusTimedout:
+			suite.Failures++
+		case StatusInterrupted, StatusAborted, StatusPanicked:
+			suite.Errors++
+		}
+	}
+
+	succeeded := fmt.Sprintf("%t", suite.Failures == 0 && suite.Errors == 0)
+	for i, property := range suite.Properties.Properties {
+		if strings.EqualFold(property.Name, "SuiteSucceeded") {
+			suite.Properties.Properties[i].Value = succeeded
+		}
+	}
+}
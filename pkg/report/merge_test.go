@@ -0,0 +1,107 @@
+package report
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+)
+
+func testCase(name, status string) JUnitTestCase {
+	tc := JUnitTestCase{JUnitTestCase: reporters.JUnitTestCase{Name: name, Status: status}}
+	switch status {
+	case StatusSkipped:
+		tc.Skipped = &reporters.JUnitSkipped{Message: "skipped"}
+	case StatusFailed:
+		tc.Failure = &reporters.JUnitFailure{Message: "boom", Type: "failed"}
+	case StatusPanicked:
+		tc.Error = &reporters.JUnitError{Message: "panic", Type: "panicked"}
+	}
+	return tc
+}
+
+func shardReport(timestamp string, cases ...JUnitTestCase) *JUnitTestSuites {
+	return &JUnitTestSuites{TestSuites: []JUnitTestSuite{{
+		JUnitTestSuite: reporters.JUnitTestSuite{
+			Name:      "HyperFleet E2E Suite",
+			Time:      10,
+			Timestamp: timestamp,
+			Properties: reporters.JUnitProperties{Properties: []reporters.JUnitProperty{
+				{Name: "SuiteSucceeded", Value: "true"},
+			}},
+		},
+		TestCases: cases,
+	}}}
+}
+
+func TestMerge(t *testing.T) {
+	shard0 := shardReport("2026-10-16T10:00:05",
+		testCase("[BeforeSuite]", StatusPassed),
+		testCase("[It] cluster A", StatusPassed),
+		testCase("[It] cluster B", StatusSkipped),
+		testCase("[It] nodepool C", StatusSkipped),
+	)
+	shard1 := shardReport("2026-10-16T10:00:00",
+		testCase("[BeforeSuite]", StatusPanicked),
+		testCase("[It] cluster A", StatusSkipped),
+		testCase("[It] cluster B", StatusFailed),
+		testCase("[It] nodepool C", StatusSkipped),
+	)
+
+	merged, err := Merge([]*JUnitTestSuites{shard0, shard1})
+	if err != nil {
+		t.Fatalf("Merge() error: %v", err)
+	}
+	if len(merged.TestSuites) != 1 {
+		t.Fatalf("Merge() returned %d suites, want 1", len(merged.TestSuites))
+	}
+
+	suite := merged.TestSuites[0]
+	wantStatus := []string{StatusPanicked, StatusPassed, StatusFailed, StatusSkipped}
+	if len(suite.TestCases) != len(wantStatus) {
+		t.Fatalf("merged suite has %d test cases, want %d", len(suite.TestCases), len(wantStatus))
+	}
+	for i, want := range wantStatus {
+		if got := suite.TestCases[i].Status; got != want {
+			t.Errorf("test case %q status = %q, want %q", suite.TestCases[i].Name, got, want)
+		}
+	}
+
+	if suite.Tests != 4 || suite.Failures != 1 || suite.Errors != 1 || suite.Skipped != 1 {
+		t.Errorf("suite counts tests=%d failures=%d errors=%d skipped=%d, want 4/1/1/1",
+			suite.Tests, suite.Failures, suite.Errors, suite.Skipped)
+	}
+	if merged.Tests != 4 || merged.Failures != 1 || merged.Errors != 1 || merged.Disabled != 1 {
+		t.Errorf("document counts tests=%d failures=%d errors=%d disabled=%d, want 4/1/1/1",
+			merged.Tests, merged.Failures, merged.Errors, merged.Disabled)
+	}
+	if suite.Time != 20 || suite.Timestamp != "2026-10-16T10:00:00" {
+		t.Errorf("suite time=%v timestamp=%q, want summed time and earliest timestamp", suite.Time, suite.Timestamp)
+	}
+	if got := suite.Properties.WithName("SuiteSucceeded"); got != "false" {
+		t.Errorf("SuiteSucceeded = %q, want false", got)
+	}
+}
+
+func TestMergeNoReports(t *testing.T) {
+	if _, err := Merge(nil); err == nil {
+		t.Errorf("Merge(nil) succeeded, want error")
+	}
+}
+
+func TestJUnitRoundTripKeepsProperties(t *testing.T) {
+	tc := testCase("[It] cluster A", StatusPassed)
+	tc.SetProperty("attempts", "2")
+
+	path := filepath.Join(t.TempDir(), "nested", "junit.xml")
+	if err := WriteJUnit(path, shardReport("", tc)); err != nil {
+		t.Fatalf("WriteJUnit() error: %v", err)
+	}
+	suites, err := ReadJUnit(path)
+	if err != nil {
+		t.Fatalf("ReadJUnit() error: %v", err)
+	}
+	if got := suites.TestSuites[0].TestCases[0].Property("attempts"); got != "2" {
+		t.Errorf("attempts property = %q after round trip, want 2", got)
+	}
+}
@@ -0,0 +1,194 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// suiteTagPattern extracts the suite name from the "[Suite: <name>]" tag in top-level Describe texts
+var suiteTagPattern = regexp.MustCompile(`\[Suite:\s*([^\]]+)\]`)
+
+// SuiteName returns the suite name from the first text carrying a "[Suite: ...]" tag
+func SuiteName(texts []string) string {
+	for _, text := range texts {
+		if match := suiteTagPattern.FindStringSubmatch(text); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+	return ""
+}
+
+// Result is the outcome of a spec read from a JUnit or Ginkgo JSON report
+type Result struct {
+	Source          string // report file the result was read from
+	Suite           string // from the "[Suite: ...]" tag
+	Name            string
+	Labels          []string
+	State           string
+	DurationSeconds float64
+	Attempts        int
+	Failure         string
+	FailureLocation string
+	NonBlocking     string // why the failure did not fail the run: informing, flaky or quarantined
+	Steps           []Step // only available from Ginkgo JSON reports
+	Output          string // GinkgoWriter output; JUnit reports carry the rendered spec timeline
+	Artifacts       []string
+}
+
+// Failed reports whether the spec failed, panicked, timed out or was interrupted
+func (r Result) Failed() bool {
+	switch r.State {
+	case StatusPassed, StatusSkipped, StatusPending:
+		return false
+	}
+	return true
+}
+
+// blockingFailure reports whether the spec failed and its failure failed the run
+func (r Result) blockingFailure() bool {
+	return r.Failed() && r.NonBlocking == ""
+}
+
+// LoadResults reads the spec results of a JUnit XML or Ginkgo JSON report, detected by content
+// Suite setup and teardown nodes are only included when they failed
+func LoadResults(path string) ([]Result, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a report location chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '<' {
+		suites, err := ReadJUnit(path)
+		if err != nil {
+			return nil, err
+		}
+		return junitResults(path, suites), nil
+	}
+
+	var reports []types.Report
+	if err := json.Unmarshal(trimmed, &reports); err != nil {
+		return nil, fmt.Errorf("failed to parse %s as a JUnit or Ginkgo JSON report: %w", path, err)
+	}
+	var results []Result
+	for _, suiteReport := range reports {
+		results = append(results, ginkgoResults(path, suiteReport)...)
+	}
+	return results, nil
+}
+
+func ginkgoResults(path string, suiteReport types.Report) []Result {
+	var results []Result
+	for _, spec := range suiteReport.SpecReports {
+		failed := spec.State.Is(types.SpecStateFailureStates)
+		if spec.LeafNodeType != types.NodeTypeIt && !failed {
+			continue
+		}
+
+		result := Result{
+			Source:          path,
+			Suite:           SuiteName(spec.ContainerHierarchyTexts),
+			Name:            SpecName(spec),
+			Labels:          spec.Labels(),
+			State:           spec.State.String(),
+			DurationSeconds: seconds(spec.RunTime),
+			Attempts:        spec.NumAttempts,
+			Steps:           steps(spec),
+			Output:          spec.CapturedGinkgoWriterOutput + spec.CapturedStdOutErr,
+		}
+		if failed {
+			result.Failure = spec.FailureMessage()
+			result.FailureLocation = spec.FailureLocation().String()
+		}
+		for _, entry := range spec.ReportEntries {
+			switch entry.Name {
+			case ReportEntryArtifact:
+				result.Artifacts = append(result.Artifacts, entry.StringRepresentation())
+			case ReportEntryNonBlocking:
+				if failed {
+					result.NonBlocking = entry.StringRepresentation()
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func junitResults(path string, suites *JUnitTestSuites) []Result {
+	var results []Result
+	for _, suite := range suites.TestSuites {
+		for _, testCase := range suite.TestCases {
+			name, labels, isSpec := parseTestCaseName(testCase.Name)
+			failed := testCase.Failure != nil || testCase.Error != nil
+			if !isSpec && !failed {
+				continue
+			}
+
+			result := Result{
+				Source:          path,
+				Suite:           SuiteName([]string{name}),
+				Name:            name,
+				Labels:          labels,
+				State:           testCase.Status,
+				DurationSeconds: testCase.Time,
+				Output:          testCase.SystemErr + testCase.SystemOut,
+			}
+			if attempts, err := strconv.Atoi(testCase.Property(PropertyAttempts)); err == nil {
+				result.Attempts = attempts
+			}
+			switch {
+			case testCase.Failure != nil:
+				result.Failure = testCase.Failure.Description
+				result.NonBlocking = nonBlockingReason(testCase.Failure.Type)
+			case testCase.Error != nil:
+				result.Failure = testCase.Error.Description
+				result.NonBlocking = nonBlockingReason(testCase.Error.Type)
+			}
+			if testCase.Properties != nil {
+				for _, property := range testCase.Properties.Properties {
+					if property.Name == PropertyArtifact {
+						result.Artifacts = append(result.Artifacts, property.Value)
+					}
+				}
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// nonBlockingReason returns the reason prefixed to a JUnit failure type of a non-blocking spec,
+// e.g. "informing" for "informing-failed", or "" when the failure blocked the run.
+// Ginkgo failure types ("failed", "timedout", ...) never contain a dash.
+func nonBlockingReason(failureType string) string {
+	reason, _, found := strings.Cut(failureType, "-")
+	if !found {
+		return ""
+	}
+	return reason
+}
+
+// parseTestCaseName splits a Ginkgo JUnit test case name such as "[It] text [label1, label2]"
+// into the spec text and labels. Suite nodes keep their bracketed node type as name.
+func parseTestCaseName(name string) (text string, labels []string, isSpec bool) {
+	const itPrefix = "[It] "
+	if !strings.HasPrefix(name, itPrefix) {
+		return name, nil, false
+	}
+	text = strings.TrimPrefix(name, itPrefix)
+	if strings.HasSuffix(text, "]") {
+		if i := strings.LastIndex(text, " ["); i >= 0 {
+			labels = strings.Split(text[i+2:len(text)-1], ", ")
+			text = text[:i]
+		}
+	}
+	return text, labels, true
+}
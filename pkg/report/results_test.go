@@ -0,0 +1,111 @@
+package report
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// resultsReport is a suite with a passed spec that saved an artifact, a failed spec and a passed suite node
+func resultsReport() types.Report {
+	passed := summarySpec("creates a cluster", types.SpecStatePassed, "tier0")
+	passed.ContainerHierarchyTexts = []string{"[Suite: cluster] Creation"}
+	passed.SpecEvents = types.SpecEvents{event(1, types.SpecEventByStart, "create cluster", 1)}
+	passed.CapturedGinkgoWriterOutput = "cluster created\n"
+	passed.ReportEntries = types.ReportEntries{{Name: ReportEntryArtifact, Value: types.WrapEntryValue("adapter-ab12")}}
+
+	failed := summarySpec("reports adapter failure", types.SpecStateFailed, "tier1", "negative")
+	failed.ContainerHierarchyTexts = []string{"[Suite: adapter] Failures"}
+	failed.Failure = types.Failure{Message: "expected False", Location: types.CodeLocation{FileName: "/src/e2e/adapter/failure.go", LineNumber: 7}}
+
+	return types.Report{
+		SuiteDescription: "HyperFleet E2E Suite",
+		SpecReports: types.SpecReports{
+			{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed},
+			passed,
+			failed,
+		},
+	}
+}
+
+func TestLoadResultsFromGinkgoJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JSONReportFile)
+	if err := reporters.GenerateJSONReport(resultsReport(), path); err != nil {
+		t.Fatalf("GenerateJSONReport() error: %v", err)
+	}
+
+	results, err := LoadResults(path)
+	if err != nil {
+		t.Fatalf("LoadResults() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("LoadResults() = %d results, want the two specs without the passed suite node", len(results))
+	}
+
+	passed := results[0]
+	if passed.Suite != "cluster" || passed.Name != "[Suite: cluster] Creation creates a cluster" || passed.State != StatusPassed {
+		t.Errorf("passed spec = %+v", passed)
+	}
+	if len(passed.Steps) != 1 || passed.Output != "cluster created\n" {
+		t.Errorf("passed spec steps = %+v, output = %q", passed.Steps, passed.Output)
+	}
+	if !reflect.DeepEqual(passed.Artifacts, []string{"adapter-ab12"}) {
+		t.Errorf("passed spec artifacts = %v, want [adapter-ab12]", passed.Artifacts)
+	}
+
+	failed := results[1]
+	if !failed.Failed() || failed.Failure != "expected False" || failed.FailureLocation != "/src/e2e/adapter/failure.go:7" {
+		t.Errorf("failed spec = %+v", failed)
+	}
+}
+
+func TestLoadResultsFromJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.xml")
+	if err := reporters.GenerateJUnitReportWithConfig(resultsReport(), path, reporters.JunitReportConfig{}); err != nil {
+		t.Fatalf("GenerateJUnitReportWithConfig() error: %v", err)
+	}
+
+	results, err := LoadResults(path)
+	if err != nil {
+		t.Fatalf("LoadResults() error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("LoadResults() = %d results, want 2", len(results))
+	}
+
+	failed := results[1]
+	if failed.Name != "[Suite: adapter] Failures reports adapter failure" || failed.Suite != "adapter" {
+		t.Errorf("failed spec name = %q, suite = %q", failed.Name, failed.Suite)
+	}
+	if !reflect.DeepEqual(failed.Labels, []string{"tier1", "negative"}) {
+		t.Errorf("failed spec labels = %v, want [tier1 negative]", failed.Labels)
+	}
+	if !failed.Failed() || failed.DurationSeconds != 10 {
+		t.Errorf("failed spec = %+v", failed)
+	}
+}
+
+func TestParseTestCaseName(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantText   string
+		wantLabels []string
+		wantSpec   bool
+	}{
+		{name: "[It] [Suite: cluster] creates [tier0, slow]", wantText: "[Suite: cluster] creates", wantLabels: []string{"tier0", "slow"}, wantSpec: true},
+		{name: "[It] [Suite: cluster] creates", wantText: "[Suite: cluster] creates", wantSpec: true},
+		{name: "[BeforeSuite]", wantText: "[BeforeSuite]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, labels, isSpec := parseTestCaseName(tt.name)
+			if text != tt.wantText || !reflect.DeepEqual(labels, tt.wantLabels) || isSpec != tt.wantSpec {
+				t.Errorf("parseTestCaseName() = %q, %v, %t; want %q, %v, %t", text, labels, isSpec, tt.wantText, tt.wantLabels, tt.wantSpec)
+			}
+		})
+	}
+}
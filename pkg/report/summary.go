@@ -0,0 +1,289 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+// Files the test command writes to the output directory
+const (
+	JSONReportFile      = "report.json" // Ginkgo JSON report
+	SummaryFile         = "summary.json"
+	SummaryMarkdownFile = "summary.md"
+)
+
+// Summary is the compact, machine-readable result of a run, written as summary.json
+type Summary struct {
+	Suite           string            `json:"suite"`
+	RunID           string            `json:"runId,omitempty"` // e2e.hyperfleet.io/run-id label of created resources
+	Passed          bool              `json:"passed"`          // the exit code of the run was 0
+	StartTime       time.Time         `json:"startTime"`
+	EndTime         time.Time         `json:"endTime"`
+	DurationSeconds float64           `json:"durationSeconds"`
+	LabelFilter     string            `json:"labelFilter,omitempty"`
+	Totals          Counts            `json:"totals"`
+	Labels          map[string]Counts `json:"labels,omitempty"`
+	Failures        []Failure         `json:"failures,omitempty"`
+	Specs           []SpecSummary     `json:"specs"`
+	Interrupted     bool              `json:"interrupted,omitempty"` // SIGINT/SIGTERM cut the run short
+	Cleanups        []Cleanup         `json:"cleanups,omitempty"`    // resources of an interrupted run
+	Config          map[string]string `json:"config,omitempty"`      // redacted effective configuration
+	Versions        *Versions         `json:"versions,omitempty"`
+}
+
+// Versions identifies the framework build that ran and the API it tested
+type Versions struct {
+	Framework version.Info        `json:"framework"`
+	ServerAPI *version.APIVersion `json:"serverApi,omitempty"` // nil when the API version was not checked
+}
+
+// Counts tallies spec results
+type Counts struct {
+	Total             int `json:"total"`
+	Passed            int `json:"passed"`
+	Failed            int `json:"failed"`
+	NonBlockingFailed int `json:"nonBlockingFailed"`
+	Skipped           int `json:"skipped"`
+	Pending           int `json:"pending"`
+	PassedOnRetry     int `json:"passedOnRetry"`
+}
+
+// Failure is a failed spec or suite node with where it failed
+type Failure struct {
+	Name            string `json:"name"`
+	State           string `json:"state"`
+	NonBlocking     string `json:"nonBlocking,omitempty"` // informing, flaky or quarantined
+	Location        string `json:"location"`
+	FailureLocation string `json:"failureLocation"`
+	Message         string `json:"message"`
+}
+
+// ReportEntryCleanups is the AfterSuite report entry listing the resources of an interrupted run and
+// whether their cleanup completed
+const ReportEntryCleanups = "cleanups"
+
+// Cleanup is a resource created by a spec of an interrupted run
+type Cleanup struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Spec      string `json:"spec,omitempty"`
+	Completed bool   `json:"completed"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SpecSummary is the result and step timeline of a single spec
+type SpecSummary struct {
+	Name            string   `json:"name"`
+	Labels          []string `json:"labels,omitempty"`
+	State           string   `json:"state"`
+	Attempts        int      `json:"attempts,omitempty"`
+	Location        string   `json:"location"`
+	DurationSeconds float64  `json:"durationSeconds"`
+	Steps           []Step   `json:"steps,omitempty"`
+}
+
+// Step is a ginkgo.By step of the last attempt of a spec
+type Step struct {
+	Text            string  `json:"text"`
+	Location        string  `json:"location"`
+	OffsetSeconds   float64 `json:"offsetSeconds"` // since the start of the spec
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// NonBlockingFunc returns why a failed spec does not fail the run, or "" if it does
+type NonBlockingFunc func(spec types.SpecReport) string
+
+// NewSummary summarizes a Ginkgo suite report
+// Totals and label counts cover specs; failures also include suite setup and teardown nodes
+func NewSummary(suiteReport types.Report, passed bool, nonBlocking NonBlockingFunc) Summary {
+	summary := Summary{
+		Suite:           suiteReport.SuiteDescription,
+		Passed:          passed,
+		StartTime:       suiteReport.StartTime,
+		EndTime:         suiteReport.EndTime,
+		DurationSeconds: seconds(suiteReport.RunTime),
+		LabelFilter:     suiteReport.SuiteConfig.LabelFilter,
+		Labels:          make(map[string]Counts),
+		Specs:           []SpecSummary{},
+	}
+
+	for _, spec := range suiteReport.SpecReports {
+		if spec.State == types.SpecStateInterrupted {
+			summary.Interrupted = true
+		}
+		summary.Cleanups = append(summary.Cleanups, cleanups(spec)...)
+
+		reason := ""
+		if spec.State.Is(types.SpecStateFailureStates) {
+			reason = nonBlocking(spec)
+			summary.Failures = append(summary.Failures, Failure{
+				Name:            SpecName(spec),
+				State:           spec.State.String(),
+				NonBlocking:     reason,
+				Location:        spec.LeafNodeLocation.String(),
+				FailureLocation: spec.FailureLocation().String(),
+				Message:         spec.FailureMessage(),
+			})
+		}
+
+		if spec.LeafNodeType != types.NodeTypeIt {
+			continue
+		}
+
+		summary.Totals.add(spec, reason)
+		for _, label := range spec.Labels() {
+			counts := summary.Labels[label]
+			counts.add(spec, reason)
+			summary.Labels[label] = counts
+		}
+
+		summary.Specs = append(summary.Specs, SpecSummary{
+			Name:            SpecName(spec),
+			Labels:          spec.Labels(),
+			State:           spec.State.String(),
+			Attempts:        spec.NumAttempts,
+			Location:        spec.LeafNodeLocation.String(),
+			DurationSeconds: seconds(spec.RunTime),
+			Steps:           steps(spec),
+		})
+	}
+	return summary
+}
+
+// cleanups returns the cleanups a suite node recorded, every parallel process records its own
+func cleanups(spec types.SpecReport) []Cleanup {
+	var result []Cleanup
+	for _, entry := range spec.ReportEntries {
+		if entry.Name != ReportEntryCleanups {
+			continue
+		}
+		// The raw value is a []Cleanup in process, decoded JSON when it comes from another process
+		data, err := json.Marshal(entry.Value.GetRawValue())
+		if err != nil {
+			continue
+		}
+		var recorded []Cleanup
+		if err := json.Unmarshal(data, &recorded); err == nil {
+			result = append(result, recorded...)
+		}
+	}
+	return result
+}
+
+// add counts one spec, reason is its non-blocking reason if it failed
+func (c *Counts) add(spec types.SpecReport, reason string) {
+	c.Total++
+	switch {
+	case spec.State == types.SpecStatePassed:
+		c.Passed++
+		if spec.NumAttempts > 1 {
+			c.PassedOnRetry++
+		}
+	case spec.State == types.SpecStateSkipped:
+		c.Skipped++
+	case spec.State == types.SpecStatePending:
+		c.Pending++
+	case reason != "":
+		c.NonBlockingFailed++
+	default:
+		c.Failed++
+	}
+}
+
+// steps returns the ginkgo.By steps of the last attempt of a spec
+// By without a callback has no end event, such a step lasts until the next step or the end of its node
+func steps(spec types.SpecReport) []Step {
+	events := append(types.SpecEvents{}, spec.SpecEvents...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].TimelineLocation.Order < events[j].TimelineLocation.Order
+	})
+
+	var result []Step
+	var starts []time.Time
+	open := -1
+	closeOpen := func(at time.Time) {
+		if open >= 0 && !at.IsZero() {
+			result[open].DurationSeconds = seconds(at.Sub(starts[open]))
+		}
+		open = -1
+	}
+
+	for _, event := range events {
+		switch event.SpecEventType {
+		case types.SpecEventSpecRetry, types.SpecEventSpecRepeat:
+			result, starts, open = nil, nil, -1
+		case types.SpecEventByStart:
+			at := event.TimelineLocation.Time
+			closeOpen(at)
+			result = append(result, Step{
+				Text:          event.Message,
+				Location:      event.CodeLocation.String(),
+				OffsetSeconds: seconds(at.Sub(spec.StartTime)),
+			})
+			starts = append(starts, at)
+			open = len(result) - 1
+		case types.SpecEventByEnd:
+			// By with a callback reports its own duration
+			for i := len(result) - 1; i >= 0; i-- {
+				if result[i].Text == event.Message && result[i].Location == event.CodeLocation.String() {
+					result[i].DurationSeconds = seconds(event.Duration)
+					break
+				}
+			}
+			open = -1
+		case types.SpecEventNodeEnd:
+			closeOpen(event.TimelineLocation.Time)
+		}
+	}
+	closeOpen(spec.EndTime)
+	return result
+}
+
+// SpecName returns the full text of a spec, or the node type for suite setup and teardown nodes
+func SpecName(spec types.SpecReport) string {
+	if text := spec.FullText(); text != "" {
+		return text
+	}
+	return "[" + spec.LeafNodeType.String() + "]"
+}
+
+// seconds converts a duration to seconds, rounded to milliseconds
+func seconds(d time.Duration) float64 {
+	return d.Round(time.Millisecond).Seconds()
+}
+
+// ReadSummary parses a summary.json file
+func ReadSummary(path string) (*Summary, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a report location chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read summary: %w", err)
+	}
+	var summary Summary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse summary %s: %w", path, err)
+	}
+	return &summary, nil
+}
+
+// WriteSummary writes a summary as indented JSON
+func WriteSummary(path string, summary Summary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	return nil
+}
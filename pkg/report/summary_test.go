@@ -0,0 +1,242 @@
+package report
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+
+	"github.com/openshift-hyperfleet/hyperfleet-e2e/pkg/version"
+)
+
+var specStart = time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+// event returns a spec event at offset seconds into the spec
+func event(order int, eventType types.SpecEventType, message string, offset float64) types.SpecEvent {
+	return types.SpecEvent{
+		SpecEventType: eventType,
+		Message:       message,
+		CodeLocation:  types.CodeLocation{FileName: "/src/e2e/cluster/creation.go", LineNumber: order},
+		TimelineLocation: types.TimelineLocation{
+			Order: order,
+			Time:  specStart.Add(time.Duration(offset * float64(time.Second))),
+		},
+	}
+}
+
+func summarySpec(text string, state types.SpecState, labels ...string) types.SpecReport {
+	return types.SpecReport{
+		LeafNodeType:            types.NodeTypeIt,
+		ContainerHierarchyTexts: []string{"[Suite: cluster]"},
+		LeafNodeText:            text,
+		LeafNodeLabels:          labels,
+		State:                   state,
+		NumAttempts:             1,
+		StartTime:               specStart,
+		EndTime:                 specStart.Add(10 * time.Second),
+		RunTime:                 10 * time.Second,
+	}
+}
+
+func TestNewSummary(t *testing.T) {
+	failed := summarySpec("fails", types.SpecStateFailed, "tier0")
+	failed.Failure = types.Failure{Message: "expected Ready", Location: types.CodeLocation{FileName: "/src/e2e/cluster/creation.go", LineNumber: 42}}
+	informing := summarySpec("informs", types.SpecStateFailed, "tier1", "informing")
+	retried := summarySpec("retried", types.SpecStatePassed, "tier0")
+	retried.NumAttempts = 2
+
+	suiteReport := types.Report{
+		SuiteDescription: "HyperFleet E2E Suite",
+		RunTime:          90 * time.Second,
+		SpecReports: types.SpecReports{
+			{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed},
+			summarySpec("passes", types.SpecStatePassed, "tier0"),
+			failed,
+			informing,
+			retried,
+			summarySpec("skipped", types.SpecStateSkipped, "tier2"),
+		},
+	}
+	nonBlocking := func(spec types.SpecReport) string {
+		if spec.LeafNodeText == "informs" {
+			return "informing"
+		}
+		return ""
+	}
+
+	summary := NewSummary(suiteReport, false, nonBlocking)
+
+	wantTotals := Counts{Total: 5, Passed: 2, Failed: 1, NonBlockingFailed: 1, Skipped: 1, PassedOnRetry: 1}
+	if summary.Totals != wantTotals {
+		t.Errorf("Totals = %+v, want %+v", summary.Totals, wantTotals)
+	}
+	if got := summary.Labels["tier0"]; got != (Counts{Total: 3, Passed: 2, Failed: 1, PassedOnRetry: 1}) {
+		t.Errorf("tier0 counts = %+v", got)
+	}
+	if len(summary.Failures) != 2 {
+		t.Fatalf("Failures = %+v, want the hard and the informing failure", summary.Failures)
+	}
+	if f := summary.Failures[0]; f.Name != "[Suite: cluster] fails" || f.FailureLocation != "/src/e2e/cluster/creation.go:42" || f.Message != "expected Ready" {
+		t.Errorf("first failure = %+v", f)
+	}
+	if summary.Failures[1].NonBlocking != "informing" {
+		t.Errorf("informing failure not marked non-blocking: %+v", summary.Failures[1])
+	}
+	if len(summary.Specs) != 5 || summary.DurationSeconds != 90 {
+		t.Errorf("Specs = %d, duration = %v; want 5 specs and 90s", len(summary.Specs), summary.DurationSeconds)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	spec := summarySpec("creates a cluster", types.SpecStatePassed)
+	spec.SpecEvents = types.SpecEvents{
+		event(1, types.SpecEventNodeStart, "", 0),
+		event(2, types.SpecEventByStart, "create cluster", 1),
+		event(3, types.SpecEventByStart, "wait for Ready", 3),
+		event(4, types.SpecEventNodeEnd, "", 8),
+	}
+
+	got := steps(spec)
+	want := []Step{
+		{Text: "create cluster", Location: "/src/e2e/cluster/creation.go:2", OffsetSeconds: 1, DurationSeconds: 2},
+		{Text: "wait for Ready", Location: "/src/e2e/cluster/creation.go:3", OffsetSeconds: 3, DurationSeconds: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("steps() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("step %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStepsKeepLastAttempt(t *testing.T) {
+	spec := summarySpec("creates a cluster", types.SpecStatePassed)
+	byEnd := event(4, types.SpecEventByEnd, "retry step", 0)
+	byEnd.CodeLocation.LineNumber = 3
+	byEnd.Duration = 1500 * time.Millisecond
+	spec.SpecEvents = types.SpecEvents{
+		event(1, types.SpecEventByStart, "first attempt", 0),
+		event(2, types.SpecEventSpecRetry, "", 4),
+		event(3, types.SpecEventByStart, "retry step", 5),
+		byEnd,
+	}
+
+	got := steps(spec)
+	if len(got) != 1 || got[0].Text != "retry step" || got[0].DurationSeconds != 1.5 {
+		t.Errorf("steps() = %+v, want only the retry step lasting 1.5s", got)
+	}
+}
+
+func TestSummaryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", SummaryFile)
+	summary := NewSummary(types.Report{SpecReports: types.SpecReports{summarySpec("passes", types.SpecStatePassed, "tier0")}},
+		true, func(types.SpecReport) string { return "" })
+	summary.Config = map[string]string{"api.url": "https://api.example.com"}
+
+	if err := WriteSummary(path, summary); err != nil {
+		t.Fatalf("WriteSummary() error: %v", err)
+	}
+	read, err := ReadSummary(path)
+	if err != nil {
+		t.Fatalf("ReadSummary() error: %v", err)
+	}
+	if read.Totals != summary.Totals || read.Config["api.url"] != "https://api.example.com" || len(read.Specs) != 1 {
+		t.Errorf("ReadSummary() = %+v, want %+v", read, summary)
+	}
+}
+
+func TestNewSummaryInterrupted(t *testing.T) {
+	// Each parallel process records the cleanups of its own specs in its AfterSuite; entries from other
+	// processes arrive as decoded JSON
+	afterSuite := func(value any) types.SpecReport {
+		return types.SpecReport{
+			LeafNodeType:  types.NodeTypeAfterSuite,
+			State:         types.SpecStatePassed,
+			ReportEntries: types.ReportEntries{{Name: ReportEntryCleanups, Value: types.WrapEntryValue(value)}},
+		}
+	}
+	suiteReport := types.Report{SpecReports: types.SpecReports{
+		summarySpec("creates a cluster", types.SpecStateInterrupted, "tier0"),
+		afterSuite([]Cleanup{{Kind: "cluster", Name: "c1", Spec: "creates a cluster", Completed: true}}),
+		afterSuite([]any{map[string]any{"kind": "nodepool", "name": "np1", "completed": false, "error": "timed out"}}),
+	}}
+
+	summary := NewSummary(suiteReport, false, func(types.SpecReport) string { return "" })
+	if !summary.Interrupted {
+		t.Error("Interrupted = false, want true")
+	}
+	want := []Cleanup{
+		{Kind: "cluster", Name: "c1", Spec: "creates a cluster", Completed: true},
+		{Kind: "nodepool", Name: "np1", Error: "timed out"},
+	}
+	if len(summary.Cleanups) != len(want) || summary.Cleanups[0] != want[0] || summary.Cleanups[1] != want[1] {
+		t.Errorf("Cleanups = %+v, want %+v", summary.Cleanups, want)
+	}
+
+	var out strings.Builder
+	RenderMarkdown(&out, summary)
+	for _, want := range []string{"### Cleanup after interrupt", "- [x] cluster `c1`", "- [ ] nodepool `np1`: timed out"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("markdown does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	failed := summarySpec("fails | badly", types.SpecStateFailed, "tier0")
+	failed.Failure = types.Failure{Message: "expected Ready", Location: types.CodeLocation{FileName: "/src/e2e/cluster/creation.go", LineNumber: 42}}
+	failed.SpecEvents = types.SpecEvents{event(1, types.SpecEventByStart, "create cluster", 1)}
+	summary := NewSummary(types.Report{
+		SuiteDescription: "HyperFleet E2E Suite",
+		SpecReports:      types.SpecReports{failed, summarySpec("skipped", types.SpecStateSkipped, "tier2")},
+	}, false, func(types.SpecReport) string { return "" })
+
+	var out strings.Builder
+	RenderMarkdown(&out, summary)
+	markdown := out.String()
+
+	for _, want := range []string{
+		"## HyperFleet E2E Suite: FAILED",
+		"Ran 1 specs in 0s",
+		"| **all** | 0 | 1 | 0 | 0 | 1 |",
+		"| `tier0` | 0 | 1 | 0 | 0 | 0 |",
+		`- **[Suite: cluster] fails \| badly** (failed) at ` + "`/src/e2e/cluster/creation.go:42`",
+		"  expected Ready",
+		`<details><summary>FAILED 10s: [Suite: cluster] fails \| badly</summary>`,
+		"| +1s | 9s | create cluster |",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown does not contain %q:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "summary>SKIPPED") {
+		t.Errorf("skipped specs should not have a timeline:\n%s", markdown)
+	}
+
+	out.Reset()
+	RenderMarkdown(&out, NewSummary(types.Report{SpecReports: types.SpecReports{summarySpec("skipped", types.SpecStateSkipped)}},
+		true, func(types.SpecReport) string { return "" }))
+	if strings.Contains(out.String(), "### Timeline") {
+		t.Errorf("timeline rendered without any spec that ran:\n%s", out.String())
+	}
+}
+
+func TestRenderMarkdownVersions(t *testing.T) {
+	summary := NewSummary(types.Report{SpecReports: types.SpecReports{summarySpec("passes", types.SpecStatePassed)}},
+		false, func(types.SpecReport) string { return "" })
+	summary.Versions = &Versions{
+		Framework: version.Info{Version: "v0.3.0", Commit: "abc1234", OpenAPISpecVersion: "1.0.4"},
+		ServerAPI: &version.APIVersion{SpecVersion: "1.1.0"},
+	}
+
+	var out strings.Builder
+	RenderMarkdown(&out, summary)
+	want := "hyperfleet-e2e `v0.3.0` (commit `abc1234`), client OpenAPI spec `1.0.4`, server API `1.1.0`"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("markdown does not contain %q:\n%s", want, out.String())
+	}
+}
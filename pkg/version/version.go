@@ -0,0 +1,118 @@
+// Package version holds the build information of the hyperfleet-e2e binary and compares the HyperFleet API
+// under test with the OpenAPI spec the pkg/api/openapi client was generated from
+package version
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Unknown is reported for build information that was not set through ldflags
+const Unknown = "unknown"
+
+// API check modes controlling what a server API differing from the client's OpenAPI spec does
+const (
+	APICheckWarn = "warn" // log a warning
+	APICheckFail = "fail" // abort the run before any spec
+	APICheckOff  = "off"  // do not query the server
+)
+
+// ValidateAPICheck checks an API check mode
+func ValidateAPICheck(mode string) error {
+	switch mode {
+	case APICheckWarn, APICheckFail, APICheckOff:
+		return nil
+	}
+	return fmt.Errorf("invalid API version check mode %q (must be %s, %s or %s)", mode, APICheckWarn, APICheckFail, APICheckOff)
+}
+
+// Info is the build information of the binary
+type Info struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+	// OpenAPISpecHash is the sha256 of the OpenAPI spec the API client was generated from
+	OpenAPISpecHash string `json:"openapiSpecHash"`
+	// OpenAPISpecVersion is the info.version of that spec
+	OpenAPISpecVersion string `json:"openapiSpecVersion"`
+}
+
+var (
+	mu      sync.RWMutex
+	current = Info{Version: "dev", Commit: Unknown, Date: Unknown, OpenAPISpecHash: Unknown, OpenAPISpecVersion: Unknown}
+)
+
+// Set records the build information, empty fields are reported as unknown
+func Set(info Info) {
+	for _, field := range []*string{&info.Version, &info.Commit, &info.Date, &info.OpenAPISpecHash, &info.OpenAPISpecVersion} {
+		if *field == "" {
+			*field = Unknown
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = info
+}
+
+// Get returns the build information
+func Get() Info {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// String lists the build information one field per line, the way the version command prints it
+func (i Info) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Version:              %s\n", i.Version)
+	fmt.Fprintf(&b, "Commit:               %s\n", i.Commit)
+	fmt.Fprintf(&b, "Build date:           %s\n", i.Date)
+	fmt.Fprintf(&b, "OpenAPI spec version: %s\n", i.OpenAPISpecVersion)
+	fmt.Fprintf(&b, "OpenAPI spec sha256:  %s\n", i.OpenAPISpecHash)
+	return b.String()
+}
+
+// APIVersion identifies the OpenAPI document served by the HyperFleet API
+type APIVersion struct {
+	SpecHash    string `json:"specHash"`    // sha256 of the served document
+	SpecVersion string `json:"specVersion"` // its info.version, empty if it has none
+}
+
+// ParseAPIVersion identifies a served OpenAPI document, in YAML or JSON
+func ParseAPIVersion(document []byte) (APIVersion, error) {
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	if err := yaml.Unmarshal(document, &spec); err != nil {
+		return APIVersion{}, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if spec.OpenAPI == "" {
+		return APIVersion{}, fmt.Errorf("response is not an OpenAPI document")
+	}
+	sum := sha256.Sum256(document)
+	return APIVersion{SpecHash: hex.EncodeToString(sum[:]), SpecVersion: spec.Info.Version}, nil
+}
+
+// Mismatch returns how the server API differs from the spec the client was generated from, or "" if it
+// does not. The served document may be reformatted, so documents with different hashes still match when
+// they carry the same info.version. Nothing can be compared when the build does not know its spec.
+func (i Info) Mismatch(server APIVersion) string {
+	switch {
+	case i.OpenAPISpecHash != Unknown && i.OpenAPISpecHash == server.SpecHash:
+		return ""
+	case i.OpenAPISpecVersion == Unknown || server.SpecVersion == "":
+		return ""
+	case i.OpenAPISpecVersion != server.SpecVersion:
+		return fmt.Sprintf("server API version %s differs from OpenAPI spec version %s the client was generated from",
+			server.SpecVersion, i.OpenAPISpecVersion)
+	}
+	return ""
+}
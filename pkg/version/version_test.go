@@ -0,0 +1,84 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetFillsUnknown(t *testing.T) {
+	previous := Get()
+	t.Cleanup(func() { Set(previous) })
+
+	Set(Info{Version: "v1.2.0", Commit: "abc1234"})
+
+	got := Get()
+	if got.Version != "v1.2.0" || got.Commit != "abc1234" {
+		t.Errorf("Get() = %+v, want the version and commit that were set", got)
+	}
+	if got.Date != Unknown || got.OpenAPISpecHash != Unknown || got.OpenAPISpecVersion != Unknown {
+		t.Errorf("Get() = %+v, want unset fields reported as %s", got, Unknown)
+	}
+}
+
+func TestParseAPIVersion(t *testing.T) {
+	for name, document := range map[string]string{
+		"yaml": "openapi: 3.0.0\ninfo:\n  title: HyperFleet API\n  version: 1.0.4\n",
+		"json": `{"openapi": "3.0.0", "info": {"title": "HyperFleet API", "version": "1.0.4"}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseAPIVersion([]byte(document))
+			if err != nil {
+				t.Fatalf("ParseAPIVersion() error = %v", err)
+			}
+			if got.SpecVersion != "1.0.4" {
+				t.Errorf("SpecVersion = %q, want 1.0.4", got.SpecVersion)
+			}
+			if len(got.SpecHash) != 64 {
+				t.Errorf("SpecHash = %q, want a sha256", got.SpecHash)
+			}
+		})
+	}
+
+	if _, err := ParseAPIVersion([]byte(`{"kind": "Error", "reason": "not found"}`)); err == nil {
+		t.Error("ParseAPIVersion() of a non OpenAPI document succeeded, want an error")
+	}
+}
+
+func TestMismatch(t *testing.T) {
+	build := Info{OpenAPISpecHash: "aaa", OpenAPISpecVersion: "1.0.4"}
+
+	tests := []struct {
+		name   string
+		info   Info
+		server APIVersion
+		want   string
+	}{
+		{"same document", build, APIVersion{SpecHash: "aaa", SpecVersion: "1.0.4"}, ""},
+		{"reformatted document", build, APIVersion{SpecHash: "bbb", SpecVersion: "1.0.4"}, ""},
+		{"different version", build, APIVersion{SpecHash: "bbb", SpecVersion: "1.1.0"}, "server API version 1.1.0 differs"},
+		{"server without version", build, APIVersion{SpecHash: "bbb"}, ""},
+		{"unknown build spec", Info{OpenAPISpecHash: Unknown, OpenAPISpecVersion: Unknown}, APIVersion{SpecHash: "bbb", SpecVersion: "1.1.0"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.info.Mismatch(tt.server)
+			if tt.want == "" && got != "" {
+				t.Errorf("Mismatch() = %q, want none", got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("Mismatch() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAPICheck(t *testing.T) {
+	for _, mode := range []string{APICheckWarn, APICheckFail, APICheckOff} {
+		if err := ValidateAPICheck(mode); err != nil {
+			t.Errorf("ValidateAPICheck(%q) error = %v", mode, err)
+		}
+	}
+	if err := ValidateAPICheck("strict"); err == nil {
+		t.Error("ValidateAPICheck(strict) succeeded, want an error")
+	}
+}